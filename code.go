@@ -1,5 +1,7 @@
 package errors
 
+import "slices"
+
 type Code string
 
 var _ KeyValuer = Code("")
@@ -30,3 +32,17 @@ func GetCode(err error) Code {
 
 	return CodeUnset
 }
+
+// GetCodes retrieves the codes of an error tree: for each root error, the most recent code attached
+// between the top of the tree and that root error. Duplicated codes are only included once.
+// It is useful for errors created by errors.Join, where GetCode only returns the first code found.
+func GetCodes(err error) []Code {
+	var codes []Code
+	for _, v := range leafValues(err, codeKey{}) {
+		if code, ok := v.(Code); ok && !slices.Contains(codes, code) {
+			codes = append(codes, code)
+		}
+	}
+
+	return codes
+}
@@ -1,6 +1,7 @@
 package errors_test
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/arquivei/errors"
@@ -22,3 +23,30 @@ func TestGetCode(t *testing.T) {
 		t.Error("expected code 2, got", errors.GetCode(err))
 	}
 }
+
+func TestGetCodes(t *testing.T) {
+	if codes := errors.GetCodes(errors.New("some error")); codes != nil {
+		t.Errorf("expected no codes, got %v", codes)
+	}
+
+	err := errors.Join(
+		errors.With(errors.New("first"), errors.Code("FIRST"), errors.Code("FIRST_OVERRIDE")),
+		errors.New("no code"),
+		errors.With(errors.New("second"), errors.Code("SECOND")),
+		errors.With(errors.New("third"), errors.Code("SECOND")),
+	)
+	if errors.GetCode(err) != "FIRST_OVERRIDE" {
+		t.Errorf("expected FIRST_OVERRIDE, got %s", errors.GetCode(err))
+	}
+
+	expected := []errors.Code{"FIRST_OVERRIDE", "SECOND"}
+	if codes := errors.GetCodes(err); !reflect.DeepEqual(codes, expected) {
+		t.Errorf("expected %v, got %v", expected, codes)
+	}
+
+	err = errors.With(err, errors.Code("TOP"))
+	expected = []errors.Code{"TOP"}
+	if codes := errors.GetCodes(err); !reflect.DeepEqual(codes, expected) {
+		t.Errorf("expected %v, got %v", expected, codes)
+	}
+}
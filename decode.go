@@ -0,0 +1,193 @@
+package errors
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync"
+)
+
+var (
+	decodeRegistryMu    sync.RWMutex
+	registeredKeys      = make(map[string]any)
+	registeredSentinels = make(map[string]error)
+	registeredKinds     = make(map[Code]*Kind)
+
+	// ErrInvalidKV is returned by Decode when the key-value pairs are not encoded as a JSON object.
+	ErrInvalidKV = New("key-value pairs must be a JSON object")
+)
+
+// RegisterKeys registers keys so that Decode can restore them.
+// Keys are matched by their string representation. Unregistered keys are decoded as string keys.
+func RegisterKeys(keys ...any) {
+	decodeRegistryMu.Lock()
+	defer decodeRegistryMu.Unlock()
+
+	for _, key := range keys {
+		registeredKeys[stringify(key)] = key
+	}
+}
+
+// RegisterSentinels registers sentinel errors so that Decode can restore them as root errors,
+// allowing errors.Is to keep working after a serialization round trip.
+// Sentinels are matched by their error message.
+func RegisterSentinels(errs ...error) {
+	decodeRegistryMu.Lock()
+	defer decodeRegistryMu.Unlock()
+
+	for _, err := range errs {
+		registeredSentinels[err.Error()] = err
+	}
+}
+
+func registerKind(k *Kind) {
+	decodeRegistryMu.Lock()
+	defer decodeRegistryMu.Unlock()
+
+	registeredKinds[k.code] = k
+}
+
+// Decode rebuilds an error chain from its JSON representation, as produced by Error.MarshalJSON
+// or JSONFormatter. The first returned value is the decoded error and the second one is
+// set if data could not be decoded.
+//
+// The decoded error keeps its ops, severity, code and key-value pairs. Registered keys and
+// sentinels are restored (see RegisterKeys and RegisterSentinels), other keys are decoded as
+// strings and other root errors are recreated from their messages. The Kinds in the chain
+// are restored, so the decoded error matches them with Is.
+// Values are decoded using encoding/json, so numbers are decoded as float64.
+func Decode(data []byte) (error, error) {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		return nil, nil
+	}
+
+	var jsonErr jsonError
+	if err := json.Unmarshal(data, &jsonErr); err != nil {
+		return nil, With(err)
+	}
+
+	return jsonErr.toError(), nil
+}
+
+func (j jsonError) toError() error {
+	var err error
+	switch {
+	case len(j.Joined) > 0:
+		children := make([]error, 0, len(j.Joined))
+		for _, child := range j.Joined {
+			children = append(children, child.toError())
+		}
+		err = Join(children...)
+	default:
+		err = lookupSentinel(j.Root)
+		if err == nil {
+			err = New(j.Root)
+		}
+	}
+
+	var kinds []*Kind
+	for _, code := range j.Kinds {
+		if kind := lookupKind(code); kind != nil && !Is(err, kind) {
+			kinds = append(kinds, kind)
+		}
+	}
+	if j.Message != err.Error() || len(kinds) > 0 {
+		err = decodedError{msg: j.Message, err: err, kinds: kinds}
+	}
+
+	// Values are stored from the most recent to the oldest, so the override semantics are preserved.
+	// The Error is created directly to avoid adding an automatic Op or a stack trace.
+	keyvals := make([]KeyValuer, 0, len(j.Ops)+2+len(j.KV))
+	for _, op := range j.Ops {
+		keyvals = append(keyvals, op)
+	}
+	if j.Code != CodeUnset {
+		keyvals = append(keyvals, j.Code)
+	}
+	if j.Severity != SeverityUnset {
+		keyvals = append(keyvals, j.Severity)
+	}
+	keyvals = append(keyvals, j.KV...)
+	if len(keyvals) == 0 {
+		return err
+	}
+
+	return Error{err: err, keyvals: &keyvals}
+}
+
+func (kvs *jsonKVs) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if token != json.Delim('{') {
+		return ErrInvalidKV
+	}
+
+	for dec.More() {
+		token, err = dec.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := token.(string)
+
+		var value any
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+
+		*kvs = append(*kvs, KV(lookupKey(key), value))
+	}
+
+	_, err = dec.Token() // '}'
+	return err
+}
+
+func lookupKey(key string) any {
+	decodeRegistryMu.RLock()
+	defer decodeRegistryMu.RUnlock()
+
+	if registered, ok := registeredKeys[key]; ok {
+		return registered
+	}
+	return key
+}
+
+func lookupKind(code Code) *Kind {
+	decodeRegistryMu.RLock()
+	defer decodeRegistryMu.RUnlock()
+
+	return registeredKinds[code]
+}
+
+func lookupSentinel(msg string) error {
+	decodeRegistryMu.RLock()
+	defer decodeRegistryMu.RUnlock()
+
+	return registeredSentinels[msg]
+}
+
+// decodedError restores the message of an error that wrapped the root error, like
+// the ones created by fmt.Errorf, and the Kinds of the decoded error, if any.
+type decodedError struct {
+	msg   string
+	err   error
+	kinds []*Kind
+}
+
+func (e decodedError) Error() string {
+	return e.msg
+}
+
+func (e decodedError) Unwrap() error {
+	return e.err
+}
+
+func (e decodedError) Is(target error) bool {
+	for _, kind := range e.kinds {
+		if target == kind {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,158 @@
+package errors_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/arquivei/errors"
+)
+
+type decodeKeyType string
+
+var errDecodeSentinel = errors.New("sentinel error")
+
+func init() {
+	errors.RegisterKeys(decodeKeyType("registered"))
+	errors.RegisterSentinels(errDecodeSentinel)
+}
+
+func TestDecode(t *testing.T) {
+	original := errors.With(
+		fmt.Errorf("wrapped: %w", errors.With(errDecodeSentinel, errors.Op("op1"), errors.KV("key", "old value"))),
+		errors.Op("op2"),
+		errors.SeverityInput,
+		errors.Code("BAD_REQUEST"),
+		errors.KV("key", "value"),
+		errors.KV(decodeKeyType("registered"), "registered value"),
+		errors.KV(decodeKeyType("unregistered"), 42),
+	)
+
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	decoded, err := errors.Decode(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if decoded.Error() != "wrapped: sentinel error" {
+		t.Errorf("expected 'wrapped: sentinel error', got '%s'", decoded.Error())
+	}
+	if !errors.Is(decoded, errDecodeSentinel) {
+		t.Error("expected decoded error to be the sentinel error")
+	}
+	if code := errors.GetCode(decoded); code != "BAD_REQUEST" {
+		t.Errorf("expected code BAD_REQUEST, got %s", code)
+	}
+	if severity := errors.GetSeverity(decoded); severity != errors.SeverityInput {
+		t.Errorf("expected severity input, got %s", severity)
+	}
+	if ops := errors.GetOpStack(decoded); ops != "op2: op1" {
+		t.Errorf("expected ops 'op2: op1', got '%s'", ops)
+	}
+	if v := errors.ValueT[string](decoded, "key"); v != "value" {
+		t.Errorf("expected 'value', got '%s'", v)
+	}
+	if v := errors.ValueT[string](decoded, decodeKeyType("registered")); v != "registered value" {
+		t.Errorf("expected 'registered value', got '%s'", v)
+	}
+	if v := errors.ValueT[float64](decoded, "unregistered"); v != 42 {
+		t.Errorf("expected 42, got %v", v)
+	}
+	if errors.Format(decoded) != errors.Format(original) {
+		t.Errorf("expected '%s', got '%s'", errors.Format(original), errors.Format(decoded))
+	}
+
+	reencoded, err := json.Marshal(decoded)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(reencoded) != string(data) {
+		t.Errorf("expected '%s', got '%s'", data, reencoded)
+	}
+}
+
+func TestDecodeJoined(t *testing.T) {
+	data := []byte(`{"message":"first\nsecond","root":"first\nsecond","joined":[{"message":"first","root":"first","code":"FIRST"},{"message":"second","root":"second"}]}`)
+
+	decoded, err := errors.Decode(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	joined, ok := decoded.(interface{ Unwrap() []error })
+	if !ok {
+		t.Fatalf("expected joined error, got %T", decoded)
+	}
+	children := joined.Unwrap()
+	if len(children) != 2 {
+		t.Fatalf("expected 2 children, got %d", len(children))
+	}
+	if code := errors.GetCode(children[0]); code != "FIRST" {
+		t.Errorf("expected code FIRST, got %s", code)
+	}
+	if decoded.Error() != "first\nsecond" {
+		t.Errorf("expected 'first\\nsecond', got '%s'", decoded.Error())
+	}
+}
+
+func TestDecodeMultipleWrapped(t *testing.T) {
+	err := fmt.Errorf("ctx: %w, %w", errors.New("x"), errors.New("y"))
+	data, marshalErr := json.Marshal(errors.With(err, errors.NoOp, errors.KV("key", "value")))
+	if marshalErr != nil {
+		t.Fatalf("unexpected error: %v", marshalErr)
+	}
+
+	decoded, decodeErr := errors.Decode(data)
+	if decodeErr != nil {
+		t.Fatalf("unexpected error: %v", decodeErr)
+	}
+	if decoded.Error() != "ctx: x, y" {
+		t.Errorf("expected 'ctx: x, y', got '%s'", decoded.Error())
+	}
+	if roots := errors.GetRootErrors(decoded); len(roots) != 2 {
+		t.Errorf("expected 2 root errors, got %v", roots)
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{"null", `null`, false},
+		{"invalid json", `{`, true},
+		{"invalid kv", `{"message":"m","root":"m","kv":[]}`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoded, err := errors.Decode([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if decoded != nil {
+				t.Errorf("expected nil decoded error, got %v", decoded)
+			}
+		})
+	}
+}
+
+func TestRegisterKeys(t *testing.T) {
+	decoded, err := errors.Decode([]byte(`{"message":"m","root":"m","kv":{"registered":1,"other":2}}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[any]any{
+		decodeKeyType("registered"): float64(1),
+		"other":                     float64(2),
+	}
+	if got := errors.ValueMap(decoded); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
@@ -0,0 +1,18 @@
+// Package errors creates meaningful errors by attaching key-value pairs to them, much like a context.Context.
+//
+// Use With to attach an operation (Op), a Severity, a Code or any other key-value pair (KV) to an error,
+// and the Value functions (Value, ValueT, Values, ...) or getters (GetCode, GetSeverity, GetOpStack, ...)
+// to retrieve them.
+//
+// # Error trees
+//
+// Errors created by errors.Join, or by fmt.Errorf with multiple %w verbs, wrap more than one error and
+// turn the error chain into a tree. The functions of this package walk that tree depth-first, in pre-order:
+// an error is visited before the errors it wraps, and joined errors are visited in order, each one along
+// with the errors it wraps before the next one.
+//
+// This defines the precedence of values: a value closer to the top of the tree overrides the ones below it,
+// and a value in a joined error overrides the ones in the errors joined after it. For example, GetCode
+// returns the code of the first joined error that has one, unless a code was attached after joining.
+// Use GetCodes and GetRootErrors to retrieve the values of every joined error.
+package errors
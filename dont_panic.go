@@ -1,6 +1,7 @@
 package errors
 
 import (
+	"context"
 	"runtime"
 	"strings"
 )
@@ -8,6 +9,8 @@ import (
 // CodePanic is set when a panic occurs in the DontPanic function.
 var CodePanic Code = "PANIC"
 
+type panicValueKey struct{}
+
 // DontPanic executes the provided function and recovers from any panic that occurs.
 // It returns an error containing the panic information if a panic occurs,
 // else it returns nil or the error returned by func() error.
@@ -15,33 +18,85 @@ var CodePanic Code = "PANIC"
 // - Code to CodePanic
 // - Op to the operation where the panic occurred
 // - Severity to SeverityFatal
-func DontPanic[F func() | func() error](fn F) (err error) {
-	defer func() {
-		if r := recover(); r != nil {
-			err = With(Errorf("panic: %v", r), getPanicOp(), CodePanic, SeverityFatal)
-		}
-	}()
-	switch f := any(fn).(type) {
-	case func():
-		f()
-	case func() error:
-		err = f()
-	}
-	return
+// - StackTrace to the call stack of the panic, starting at the function that panicked
+//
+// The panic is recovered according to the DefaultRecoverPolicy, which can change the code and
+// severity, re-panic some values and report the error. See DontPanicWithPolicy to use another policy.
+//
+// If the panic value is an error, the returned error wraps it, so its chain is still
+// available to Is and As. The raw panic value can be retrieved with GetPanicValue.
+func DontPanic[F func() | func() error](fn F) error {
+	return DontPanicWithPolicy(DefaultRecoverPolicy, fn)
 }
 
-func getPanicOp() (op Op) {
-	// find the panic operation in the stack trace
-	callers := make([]uintptr, 10)
-	runtime.Callers(1, callers)
-	pcIdx := findPcAfterPanic(callers)
+// DontPanicT is like DontPanic, for functions that return a value and an error.
+// If a panic occurs, it returns the zero value of T and the error created from the panic.
+func DontPanicT[T any](fn func() (T, error)) (T, error) {
+	var result T
+	err := DontPanicWithPolicy(DefaultRecoverPolicy, func() error {
+		var err error
+		result, err = fn()
+		return err
+	})
+	return result, err
+}
+
+// DontPanicCtx is like DontPanic, for functions that receive a context.
+func DontPanicCtx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return DontPanicWithPolicy(DefaultRecoverPolicy, func() error {
+		return fn(ctx)
+	})
+}
+
+// GetPanicValue returns the value recovered by DontPanic, or nil if err was not created from a panic.
+func GetPanicValue(err error) any {
+	return Value(err, panicValueKey{})
+}
+
+// newPanicError creates the error for a recovered panic value. It must be called
+// by the deferred function that recovered the panic.
+func newPanicError(r any, code Code, severity Severity) error {
+	var err error
+	if e, ok := r.(error); ok {
+		err = Errorf("panic: %w", e)
+	} else {
+		err = Errorf("panic: %v", r)
+	}
 
+	stack := capturePanicStack()
+
+	return With(err, KV(panicValueKey{}, r), stack, panicOp(stack), code, severity)
+}
+
+// panicOp returns the operation where the panic occurred, given the stack trace of the panic.
+func panicOp(stack StackTrace) Op {
 	// If we can't find the panic operation, return an unknown operation
-	if pcIdx == -1 {
+	if len(stack) == 0 {
 		return opUnknownFunction
 	}
 
-	return getCallerOp(callers[pcIdx], true)
+	// The pc is the return address, so it's decremented to point to the call that panicked.
+	// For faulting instructions, the runtime already adds 1 to the pc, so this works for them too.
+	return getCallerOp(stack[0]-1, true)
+}
+
+// panicStackOffset is the number of extra frames captured to account for the
+// recovery and runtime frames that are discarded from a panic stack trace.
+const panicStackOffset = 16
+
+// capturePanicStack captures the stack trace of the current panic, starting at the function that panicked.
+// It returns nil if there is no panic in the call stack.
+func capturePanicStack() StackTrace {
+	callers := make([]uintptr, MaxStackTraceDepth+panicStackOffset)
+	n := runtime.Callers(1, callers)
+	callers = callers[:n]
+
+	pcIdx := findPcAfterPanic(callers)
+	if pcIdx == -1 || pcIdx >= len(callers) {
+		return nil
+	}
+
+	return StackTrace(callers[pcIdx:min(len(callers), pcIdx+MaxStackTraceDepth)])
 }
 
 func findPcAfterPanic(callers []uintptr) int {
@@ -48,23 +48,68 @@ func TestDontPanic(t *testing.T) {
 
 }
 
-func Test_getPanicOp(t *testing.T) {
-	op := getPanicOp()
-	if op != opUnknownFunction {
-		t.Errorf("expected unknown function, got %v", op)
-	}
+func TestDontPanicPreservesPanicValue(t *testing.T) {
+	panicErr := With(New("my panic"), Code("MY_CODE"))
 
 	err := DontPanic(func() {
-		s := []string{}
-		s[1] = "this should panic"
+		panic(panicErr)
 	})
 
-	if err == nil {
-		t.Fatal("expected non-nil error, got nil")
+	if !Is(err, panicErr) {
+		t.Error("expected error to wrap the panic error")
+	}
+	if v := GetPanicValue(err); v != panicErr {
+		t.Errorf("expected panic value %v, got %v", panicErr, v)
+	}
+	if code := GetCode(err); code != CodePanic {
+		t.Errorf("expected code %s, got %s", CodePanic, code)
+	}
+	if code := GetCode(GetPanicValue(err).(error)); code != "MY_CODE" {
+		t.Errorf("expected code MY_CODE, got %s", code)
+	}
+	if err.Error() != "panic: my panic" {
+		t.Errorf("expected 'panic: my panic', got '%s'", err.Error())
+	}
+
+	err = DontPanic(func() {
+		panic(42)
+	})
+	if v := GetPanicValue(err); v != 42 {
+		t.Errorf("expected panic value 42, got %v", v)
+	}
+	if err.Error() != "panic: 42" {
+		t.Errorf("expected 'panic: 42', got '%s'", err.Error())
+	}
+	if kvs := ValueAllSlice(err); len(kvs) != 0 {
+		t.Errorf("expected no key-value pairs, got %v", kvs)
+	}
+
+	if v := GetPanicValue(New("not a panic")); v != nil {
+		t.Errorf("expected nil panic value, got %v", v)
+	}
+}
+
+func panicForStackTrace() {
+	var m map[string]int
+	m["nil map"] = 1
+}
+
+func TestDontPanicStackTrace(t *testing.T) {
+	err := DontPanic(panicForStackTrace)
+
+	frames := GetStackTrace(err)
+	if len(frames) < 2 {
+		t.Fatalf("expected at least 2 frames, got %v", frames)
+	}
+	if frames[0].Function != "github.com/arquivei/errors.panicForStackTrace" {
+		t.Errorf("expected first frame to be panicForStackTrace, got %s", frames[0])
+	}
+	if frames[1].Function != "github.com/arquivei/errors.DontPanicWithPolicy[...]" {
+		t.Errorf("expected second frame to be DontPanicWithPolicy, got %s", frames[1])
 	}
 
-	expectedOp := Op("errors.Test_getPanicOp.func1 (dont_panic_test.go:59)")
+	expectedOp := Op("errors.panicForStackTrace (dont_panic_test.go:94)")
 	if op := ValueT[Op](err, opKey{}); op != expectedOp {
-		t.Errorf("expected operation '%s', got ;'%s'", expectedOp, op)
+		t.Errorf("expected operation '%s', got '%s'", expectedOp, op)
 	}
 }
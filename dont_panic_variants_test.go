@@ -0,0 +1,84 @@
+package errors
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"testing"
+)
+
+func TestDontPanicT(t *testing.T) {
+	v, err := DontPanicT(func() (int, error) {
+		return 42, nil
+	})
+	if v != 42 || err != nil {
+		t.Errorf("expected (42, nil), got (%d, %v)", v, err)
+	}
+
+	rootErr := New("some error")
+	v, err = DontPanicT(func() (int, error) {
+		return 1, rootErr
+	})
+	if v != 1 || err != rootErr {
+		t.Errorf("expected (1, %v), got (%d, %v)", rootErr, v, err)
+	}
+
+	var line int
+	s, err := DontPanicT(func() ([]string, error) {
+		s := []string{"a"}
+		_, _, line, _ = runtime.Caller(0)
+		return s, Errorf("%s", s[line]) // out of range
+	})
+	if s != nil {
+		t.Errorf("expected zero value, got %v", s)
+	}
+	if code := GetCode(err); code != CodePanic {
+		t.Errorf("expected code %s, got %s", CodePanic, code)
+	}
+	if severity := GetSeverity(err); severity != SeverityFatal {
+		t.Errorf("expected severity %s, got %s", SeverityFatal, severity)
+	}
+	expectedOp := Op(fmt.Sprintf("errors.TestDontPanicT.func3 (dont_panic_variants_test.go:%d)", line+1))
+	if op := ValueT[Op](err, opKey{}); op != expectedOp {
+		t.Errorf("expected operation '%s', got '%s'", expectedOp, op)
+	}
+}
+
+func TestDontPanicCtx(t *testing.T) {
+	type ctxKey struct{}
+	ctx := context.WithValue(t.Context(), ctxKey{}, "value")
+
+	err := DontPanicCtx(ctx, func(ctx context.Context) error {
+		if ctx.Value(ctxKey{}) != "value" {
+			t.Error("expected context to be passed to the function")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+
+	err = DontPanicCtx(ctx, func(ctx context.Context) error {
+		panic(ctx.Value(ctxKey{}))
+	})
+	if code := GetCode(err); code != CodePanic {
+		t.Errorf("expected code %s, got %s", CodePanic, code)
+	}
+	if v := GetPanicValue(err); v != "value" {
+		t.Errorf("expected panic value 'value', got %v", v)
+	}
+}
+
+func TestDontPanicTNilPointer(t *testing.T) {
+	var line int
+	_, err := DontPanicT(func() (int, error) {
+		var p *int
+		_, _, line, _ = runtime.Caller(0)
+		return *p, nil
+	})
+
+	expectedOp := Op(fmt.Sprintf("errors.TestDontPanicTNilPointer.func1 (dont_panic_variants_test.go:%d)", line+1))
+	if op := ValueT[Op](err, opKey{}); op != expectedOp {
+		t.Errorf("expected operation '%s', got '%s'", expectedOp, op)
+	}
+}
@@ -1,11 +1,31 @@
 package errors
 
-var _ error = Error{}
+import (
+	"fmt"
+	"io"
+	"reflect"
+)
 
-// Error is an error that wraps another error and adds a key-value pair.
+var (
+	_ error         = Error{}
+	_ fmt.Formatter = Error{}
+)
+
+// Error is an error that wraps another error and adds key-value pairs.
+// Each call to With adds a single Error with all its key-value pairs.
 type Error struct {
-	err    error
-	keyval KeyValuer
+	err error
+	// keyvals are the key-value pairs, from the most recent to the oldest.
+	// It's a pointer so Error values stay comparable.
+	keyvals *[]KeyValuer
+}
+
+// values returns the key-value pairs of the error, from the most recent to the oldest.
+func (e Error) values() []KeyValuer {
+	if e.keyvals == nil {
+		return nil
+	}
+	return *e.keyvals
 }
 
 // Error returns the error message formatted by the formatter associated with the error.
@@ -22,3 +42,52 @@ func (e Error) Error() string {
 func (e Error) Unwrap() error {
 	return e.err
 }
+
+// Format implements fmt.Formatter. The supported verbs are:
+//
+//	%s, %v  the error message, as returned by Error()
+//	%q      the quoted error message
+//	%x, %X  the hex-encoded error message
+//	%+v     the error formatted by its formatter, as returned by Format(e)
+//	%#v     a Go-syntax representation of every node of the chain
+//
+// Formatters must not format the error with %+v, as that would call the formatter again.
+func (e Error) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('#') {
+			_, _ = io.WriteString(s, "errors.Error{keyvals: []errors.KeyValuer{")
+			for i, kv := range e.values() {
+				if i > 0 {
+					_, _ = io.WriteString(s, ", ")
+				}
+				_, _ = io.WriteString(s, goSyntax(kv))
+			}
+			fmt.Fprintf(s, "}, err: %#v}", e.err)
+			return
+		}
+		if s.Flag('+') {
+			_, _ = io.WriteString(s, Format(e))
+			return
+		}
+		fmt.Fprintf(s, fmt.FormatString(s, verb), e.Error())
+	case 's', 'q', 'x', 'X':
+		fmt.Fprintf(s, fmt.FormatString(s, verb), e.Error())
+	default:
+		fmt.Fprintf(s, "%%!%c(errors.Error=%s)", verb, e.Error())
+	}
+}
+
+// goSyntax returns the Go-syntax representation of v. Unlike %#v, values of named basic
+// types, like Op and Code, are written as conversions, so their types are not lost.
+func goSyntax(v any) string {
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return fmt.Sprintf("%T(%#v)", v, v)
+	default:
+		return fmt.Sprintf("%#v", v)
+	}
+}
@@ -47,3 +47,42 @@ func TestErrorError(t *testing.T) {
 		}
 	})
 }
+
+func TestErrorFormat(t *testing.T) {
+	err := With(New("root error"), Op("op1"), SeverityInput, KV("key", "value"))
+
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{format: "%v", expected: "root error"},
+		{format: "%s", expected: "root error"},
+		{format: "%12.4s", expected: "        root"},
+		{format: "%q", expected: `"root error"`},
+		{format: "%x", expected: "726f6f74206572726f72"},
+		{format: "% X", expected: "72 6F 6F 74 20 65 72 72 6F 72"},
+		{format: "%+v", expected: "op1: [input] root error {key=value}"},
+		{format: "%d", expected: "%!d(errors.Error=root error)"},
+		{
+			format: "%#v",
+			expected: `errors.Error{keyvals: []errors.KeyValuer{errors.Op("op1"), ` +
+				`errors.KeyValue{key:"key", value:"value"}, errors.Severity("input")}, ` +
+				`err: &errors.errorString{s:"root error"}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			if got := fmt.Sprintf(tt.format, err); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+
+	t.Run("custom formatter", func(t *testing.T) {
+		err := With(err, KVFormatter)
+		expected := "root error {key=value}"
+		if got := fmt.Sprintf("%+v", err); got != expected {
+			t.Errorf("expected %q, got %q", expected, got)
+		}
+	})
+}
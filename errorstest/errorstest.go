@@ -0,0 +1,132 @@
+// Package errorstest provides test assertions for errors created with the errors package.
+//
+// The assertions report failures with t.Errorf, so the test continues, and return
+// whether they succeeded.
+package errorstest
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/arquivei/errors"
+)
+
+// AssertCode asserts that err has the given code.
+func AssertCode(t testing.TB, err error, code errors.Code) bool {
+	t.Helper()
+
+	if got := errors.GetCode(err); got != code {
+		t.Errorf("unexpected error code: got %q, want %q\nerror: %s", got, code, errors.Format(err))
+		return false
+	}
+	return true
+}
+
+// AssertSeverity asserts that err has the given severity.
+func AssertSeverity(t testing.TB, err error, severity errors.Severity) bool {
+	t.Helper()
+
+	if got := errors.GetSeverity(err); got != severity {
+		t.Errorf("unexpected error severity: got %q, want %q\nerror: %s", got, severity, errors.Format(err))
+		return false
+	}
+	return true
+}
+
+// AssertHasKV asserts that the value associated with key in err is equal to value.
+// Values are compared with reflect.DeepEqual.
+func AssertHasKV(t testing.TB, err error, key, value any) bool {
+	t.Helper()
+
+	got := errors.Value(err, key)
+	if got == nil && value != nil {
+		t.Errorf("missing error key %v: want %#v\nerror: %s", key, value, errors.Format(err))
+		return false
+	}
+	if !reflect.DeepEqual(got, value) {
+		t.Errorf("unexpected value for error key %v: got %#v, want %#v\nerror: %s", key, got, value, errors.Format(err))
+		return false
+	}
+	return true
+}
+
+// AssertOpStackContains asserts that op is in the operation stack of err.
+func AssertOpStackContains(t testing.TB, err error, op errors.Op) bool {
+	t.Helper()
+
+	ops := errors.ValuesT[errors.Op](err, errors.Op("").Key())
+	if !slices.Contains(ops, op) {
+		t.Errorf("operation %q not found in error operation stack %q\nerror: %s", op, errors.GetOpStack(err), errors.Format(err))
+		return false
+	}
+	return true
+}
+
+// Equal asserts that got and want are semantically equal: they have the same message,
+// root error message, code, severity and key-value pairs. Operations are ignored, so
+// the assertion doesn't break when functions are renamed or moved.
+// On failure, all mismatched fields are reported.
+func Equal(t testing.TB, got, want error) bool {
+	t.Helper()
+
+	if got == nil || want == nil {
+		if got != want {
+			t.Errorf("errors are not equal:\n  got:  %v\n  want: %v", got, want)
+			return false
+		}
+		return true
+	}
+
+	var diffs []string
+	diff := func(field string, got, want any) {
+		if !reflect.DeepEqual(got, want) {
+			diffs = append(diffs, fmt.Sprintf("  %s: got %#v, want %#v", field, got, want))
+		}
+	}
+
+	diff("message", got.Error(), want.Error())
+	diff("root", errors.GetRootError(got).Error(), errors.GetRootError(want).Error())
+	diff("code", errors.GetCode(got), errors.GetCode(want))
+	diff("severity", errors.GetSeverity(got), errors.GetSeverity(want))
+
+	gotKVs, wantKVs := errors.ValueMap(got), errors.ValueMap(want)
+	for _, key := range sortedKeys(gotKVs, wantKVs) {
+		gotValue, gotOK := gotKVs[key]
+		wantValue, wantOK := wantKVs[key]
+		switch {
+		case !gotOK:
+			diffs = append(diffs, fmt.Sprintf("  kv[%v]: missing, want %#v", key, wantValue))
+		case !wantOK:
+			diffs = append(diffs, fmt.Sprintf("  kv[%v]: got %#v, want missing", key, gotValue))
+		default:
+			diff(fmt.Sprintf("kv[%v]", key), gotValue, wantValue)
+		}
+	}
+
+	if len(diffs) > 0 {
+		t.Errorf("errors are not equal:\n%s\ngot:  %s\nwant: %s", strings.Join(diffs, "\n"), errors.Format(got), errors.Format(want))
+		return false
+	}
+	return true
+}
+
+// sortedKeys returns the keys of both maps sorted by their string representation.
+func sortedKeys(a, b map[any]any) []any {
+	keys := make([]any, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.SliceStable(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+	})
+	return keys
+}
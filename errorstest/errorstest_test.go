@@ -0,0 +1,119 @@
+package errorstest_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/arquivei/errors"
+	"github.com/arquivei/errors/errorstest"
+)
+
+// fakeT records failures instead of failing the test.
+type fakeT struct {
+	testing.TB
+	failures []string
+}
+
+func (t *fakeT) Helper() {}
+
+func (t *fakeT) Errorf(format string, args ...any) {
+	t.failures = append(t.failures, fmt.Sprintf(format, args...))
+}
+
+func newTestError() error {
+	return errors.With(
+		errors.New("some error"),
+		errors.Op("op1"),
+		errors.SeverityInput,
+		errors.Code("BAD_REQUEST"),
+		errors.KV("key", "value"),
+		errors.KV("slice", []int{1, 2}),
+	)
+}
+
+func TestAssertions(t *testing.T) {
+	err := errors.With(newTestError(), errors.Op("op2"))
+
+	tests := []struct {
+		name    string
+		assert  func(t testing.TB) bool
+		failure string
+	}{
+		{"code", func(t testing.TB) bool { return errorstest.AssertCode(t, err, "BAD_REQUEST") }, ""},
+		{"wrong code", func(t testing.TB) bool { return errorstest.AssertCode(t, err, "NOT_FOUND") }, `unexpected error code: got "BAD_REQUEST", want "NOT_FOUND"`},
+		{"severity", func(t testing.TB) bool { return errorstest.AssertSeverity(t, err, errors.SeverityInput) }, ""},
+		{"wrong severity", func(t testing.TB) bool { return errorstest.AssertSeverity(t, err, errors.SeverityFatal) }, `unexpected error severity: got "input", want "fatal"`},
+		{"kv", func(t testing.TB) bool { return errorstest.AssertHasKV(t, err, "slice", []int{1, 2}) }, ""},
+		{"wrong kv", func(t testing.TB) bool { return errorstest.AssertHasKV(t, err, "key", "other") }, `unexpected value for error key key: got "value", want "other"`},
+		{"missing kv", func(t testing.TB) bool { return errorstest.AssertHasKV(t, err, "missing", 1) }, `missing error key missing: want 1`},
+		{"op", func(t testing.TB) bool { return errorstest.AssertOpStackContains(t, err, "op1") }, ""},
+		{"missing op", func(t testing.TB) bool { return errorstest.AssertOpStackContains(t, err, "op3") }, `operation "op3" not found in error operation stack "op2: op1"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ft := &fakeT{}
+			ok := tt.assert(ft)
+			if ok != (tt.failure == "") {
+				t.Errorf("expected assertion result %v, got %v", tt.failure == "", ok)
+			}
+			if tt.failure == "" {
+				if len(ft.failures) != 0 {
+					t.Errorf("unexpected failures: %v", ft.failures)
+				}
+				return
+			}
+			if len(ft.failures) != 1 || !strings.HasPrefix(ft.failures[0], tt.failure) {
+				t.Errorf("expected failure starting with %q, got %q", tt.failure, ft.failures)
+			}
+		})
+	}
+}
+
+func TestEqual(t *testing.T) {
+	t.Run("ignores ops", func(t *testing.T) {
+		ft := &fakeT{}
+		want := errors.With(newTestError(), errors.Op("other op"))
+		if !errorstest.Equal(ft, newTestError(), want) {
+			t.Errorf("expected errors to be equal: %v", ft.failures)
+		}
+	})
+
+	t.Run("nil errors", func(t *testing.T) {
+		ft := &fakeT{}
+		if !errorstest.Equal(ft, nil, nil) {
+			t.Errorf("expected nil errors to be equal: %v", ft.failures)
+		}
+		if errorstest.Equal(ft, newTestError(), nil) {
+			t.Error("expected error not to be equal to nil")
+		}
+	})
+
+	t.Run("reports all differences", func(t *testing.T) {
+		ft := &fakeT{}
+		got := errors.With(
+			errors.New("some error"),
+			errors.SeverityRuntime,
+			errors.Code("BAD_REQUEST"),
+			errors.KV("key", "other value"),
+			errors.KV("extra", true),
+		)
+		if errorstest.Equal(ft, got, newTestError()) {
+			t.Fatal("expected errors not to be equal")
+		}
+		if len(ft.failures) != 1 {
+			t.Fatalf("expected 1 failure, got %v", ft.failures)
+		}
+
+		expected := strings.Join([]string{
+			"errors are not equal:",
+			`  severity: got "runtime", want "input"`,
+			`  kv[extra]: got true, want missing`,
+			`  kv[key]: got "other value", want "value"`,
+			`  kv[slice]: missing, want []int{1, 2}`,
+		}, "\n")
+		if !strings.HasPrefix(ft.failures[0], expected) {
+			t.Errorf("expected failure starting with:\n%s\ngot:\n%s", expected, ft.failures[0])
+		}
+	})
+}
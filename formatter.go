@@ -1,7 +1,5 @@
 package errors
 
-import "strings"
-
 var (
 	_ KeyValuer = Formatter(nil)
 
@@ -55,73 +53,7 @@ func GetFormatter(err error) Formatter {
 // It provides a comprehensive view of the error, including its context and any additional information that has been attached to it.
 // The format is as follows:
 // operation2: ... operation1: [severity] (code) root error message {key1: value1, key2: value2, ...}
-var FullFormater Formatter = func(err error) string {
-	sb := strings.Builder{}
-	sb.Grow(32)
-
-	writeOpStack(&sb, GetOpStack(err))
-	writeSeverity(&sb, GetSeverity(err))
-	writeCode(&sb, GetCode(err))
-
-	sb.WriteString(err.Error())
-	writeKV(&sb, ValueAllSlice(err))
-
-	return sb.String()
-}
+var FullFormater = NewFormatter()
 
 // KVFormatter formats the error's message along with its key-value pairs.
-var KVFormatter Formatter = func(err error) string {
-	sb := strings.Builder{}
-	sb.Grow(32)
-
-	sb.WriteString(err.Error())
-	writeKV(&sb, ValueAllSlice(err))
-
-	return sb.String()
-}
-
-func writeCode(sb *strings.Builder, code Code) {
-	if code == CodeUnset {
-		return
-	}
-	sb.WriteString("(")
-	sb.WriteString(code.String())
-	sb.WriteString(") ")
-}
-
-func writeSeverity(sb *strings.Builder, severity Severity) {
-	if severity == SeverityUnset {
-		return
-	}
-	sb.WriteString("[")
-	sb.WriteString(severity.String())
-	sb.WriteString("] ")
-}
-
-func writeOpStack(sb *strings.Builder, ops string) {
-	if ops == "" {
-		return
-	}
-	sb.WriteString(ops)
-	sb.WriteString(": ")
-}
-
-func writeKV(sb *strings.Builder, kvs []KeyValuer) {
-	if len(kvs) <= 0 {
-		return
-	}
-	sb.WriteString(" {")
-	shouldAddComma := false
-	for _, kv := range kvs {
-		if shouldAddComma {
-			sb.WriteString(", ")
-		}
-		sb.WriteString(stringify(kv.Key()))
-		sb.WriteString("=")
-		sb.WriteString(stringify(kv.Value()))
-
-		shouldAddComma = true
-	}
-
-	sb.WriteString("}")
-}
+var KVFormatter = NewFormatter(FormatSections(SectionMessage, SectionKV))
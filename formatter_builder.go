@@ -0,0 +1,280 @@
+package errors
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// FormatSection is a part of the output of a formatter created by NewFormatter.
+type FormatSection int
+
+const (
+	// SectionOps is the operation stack, followed by a colon: "op2: op1:".
+	// The operations of joined errors are written as branches, like GetOpStack: "op3: [op1 | op2]:".
+	SectionOps FormatSection = iota
+	// SectionSeverity is the severity between brackets: "[input]".
+	SectionSeverity
+	// SectionCode is the code between parentheses: "(BAD_REQUEST)".
+	SectionCode
+	// SectionMessage is the error message, as returned by Error().
+	SectionMessage
+	// SectionRootMessage is the message of the root error, without the messages of wrapping errors.
+	SectionRootMessage
+	// SectionKV is the key-value pairs between braces: "{key1=value1, key2=value2}".
+	SectionKV
+)
+
+// FormatterOption configures a formatter created by NewFormatter.
+type FormatterOption func(*formatterConfig)
+
+type formatterConfig struct {
+	sections         []FormatSection
+	sectionSeparator string
+	opSeparator      string
+	kvSeparator      string
+	kvAssign         string
+	sortKeys         bool
+	quoteValues      bool
+	maxLength        int
+	groupByFrame     bool
+	renderers        map[any]func(value any) string
+}
+
+// FormatSections sets which sections are included and in what order.
+// Empty sections are always omitted. The default is
+// SectionOps, SectionSeverity, SectionCode, SectionMessage and SectionKV.
+func FormatSections(sections ...FormatSection) FormatterOption {
+	return func(c *formatterConfig) {
+		c.sections = sections
+	}
+}
+
+// FormatSectionSeparator sets the separator between sections. The default is " ".
+func FormatSectionSeparator(sep string) FormatterOption {
+	return func(c *formatterConfig) {
+		c.sectionSeparator = sep
+	}
+}
+
+// FormatOpSeparator sets the separator between operations. The default is ": ".
+func FormatOpSeparator(sep string) FormatterOption {
+	return func(c *formatterConfig) {
+		c.opSeparator = sep
+	}
+}
+
+// FormatKVSeparator sets the separator between key-value pairs. The default is ", ".
+func FormatKVSeparator(sep string) FormatterOption {
+	return func(c *formatterConfig) {
+		c.kvSeparator = sep
+	}
+}
+
+// FormatKVAssign sets the separator between a key and its value. The default is "=".
+func FormatKVAssign(assign string) FormatterOption {
+	return func(c *formatterConfig) {
+		c.kvAssign = assign
+	}
+}
+
+// FormatSortKeys sorts the key-value pairs by their stringified keys instead of
+// writing them from the most recent to the oldest.
+func FormatSortKeys() FormatterOption {
+	return func(c *formatterConfig) {
+		c.sortKeys = true
+	}
+}
+
+// FormatQuoteValues writes the values of key-value pairs as quoted Go strings.
+func FormatQuoteValues() FormatterOption {
+	return func(c *formatterConfig) {
+		c.quoteValues = true
+	}
+}
+
+// FormatGroupByFrame writes the key-value pairs grouped by the Frame that attached them,
+// including the overridden ones: "{op2: key1=value2; op1: key1=value1, key2=value2}".
+func FormatGroupByFrame() FormatterOption {
+	return func(c *formatterConfig) {
+		c.groupByFrame = true
+	}
+}
+
+// FormatMaxLength limits the output to n runes. Longer outputs are truncated and end with "...".
+// Zero or negative values disable the limit, which is the default.
+func FormatMaxLength(n int) FormatterOption {
+	return func(c *formatterConfig) {
+		c.maxLength = n
+	}
+}
+
+// FormatValueRenderer sets how the values of key are written.
+// Values of other keys are stringified.
+func FormatValueRenderer(key any, render func(value any) string) FormatterOption {
+	return func(c *formatterConfig) {
+		if c.renderers == nil {
+			c.renderers = make(map[any]func(value any) string)
+		}
+		c.renderers[key] = render
+	}
+}
+
+// NewFormatter creates a Formatter from the given options. Without options, it formats errors
+// the same way as FullFormater:
+//
+//	operation2: ... operation1: [severity] (code) error message {key1=value1, key2=value2, ...}
+//
+// The formatter can be attached to errors using With or set as the DefaultFormatter.
+func NewFormatter(opts ...FormatterOption) Formatter {
+	c := defaultFormatterConfig
+	for _, opt := range opts {
+		opt(&c)
+	}
+	c.sections = slices.Clone(c.sections)
+
+	return c.format
+}
+
+var defaultFormatterConfig = formatterConfig{
+	sections:         []FormatSection{SectionOps, SectionSeverity, SectionCode, SectionMessage, SectionKV},
+	sectionSeparator: " ",
+	opSeparator:      ": ",
+	kvSeparator:      ", ",
+	kvAssign:         "=",
+}
+
+func (c formatterConfig) format(err error) string {
+	sections := make([]string, 0, len(c.sections))
+	for _, section := range c.sections {
+		if s := c.formatSection(section, err); s != "" {
+			sections = append(sections, s)
+		}
+	}
+
+	return c.truncate(strings.Join(sections, c.sectionSeparator))
+}
+
+// formatSection returns the section, or an empty string if there is nothing to write.
+func (c formatterConfig) formatSection(section FormatSection, err error) string {
+	switch section {
+	case SectionOps:
+		sb := strings.Builder{}
+		if !writeOpStack(&sb, err, c.opSeparator) {
+			return ""
+		}
+		sb.WriteString(":")
+		return sb.String()
+	case SectionSeverity:
+		if severity := GetSeverity(err); severity != SeverityUnset {
+			return "[" + severity.String() + "]"
+		}
+	case SectionCode:
+		if code := GetCode(err); code != CodeUnset {
+			return "(" + code.String() + ")"
+		}
+	case SectionMessage:
+		return err.Error()
+	case SectionRootMessage:
+		return GetRootError(err).Error()
+	case SectionKV:
+		if c.groupByFrame {
+			return c.formatFrameKVs(Frames(err))
+		}
+		return c.formatKV(ValueAllSlice(err))
+	}
+
+	return ""
+}
+
+func (c formatterConfig) formatKV(kvs []KeyValuer) string {
+	if len(kvs) == 0 {
+		return ""
+	}
+
+	sb := strings.Builder{}
+	sb.Grow(32)
+	sb.WriteString("{")
+	c.writePairs(&sb, kvs)
+	sb.WriteString("}")
+
+	return sb.String()
+}
+
+func (c formatterConfig) formatFrameKVs(frames []Frame) string {
+	sb := strings.Builder{}
+	for _, frame := range frames {
+		if len(frame.KVs) == 0 {
+			continue
+		}
+		if sb.Len() == 0 {
+			sb.WriteString("{")
+		} else {
+			sb.WriteString("; ")
+		}
+		if frame.Op != "" {
+			sb.WriteString(frame.Op.String())
+			sb.WriteString(": ")
+		}
+		c.writePairs(&sb, frame.KVs)
+	}
+	if sb.Len() == 0 {
+		return ""
+	}
+	sb.WriteString("}")
+
+	return sb.String()
+}
+
+func (c formatterConfig) writePairs(sb *strings.Builder, kvs []KeyValuer) {
+	if c.sortKeys {
+		kvs = slices.SortedStableFunc(slices.Values(kvs), func(a, b KeyValuer) int {
+			return cmp.Compare(stringify(a.Key()), stringify(b.Key()))
+		})
+	}
+
+	for i, kv := range kvs {
+		if i > 0 {
+			sb.WriteString(c.kvSeparator)
+		}
+		sb.WriteString(stringify(kv.Key()))
+		sb.WriteString(c.kvAssign)
+		sb.WriteString(c.renderValue(kv))
+	}
+}
+
+func (c formatterConfig) renderValue(kv KeyValuer) string {
+	var value string
+	if render, ok := c.renderers[kv.Key()]; ok {
+		value = render(kv.Value())
+	} else {
+		value = stringify(kv.Value())
+	}
+
+	if c.quoteValues {
+		return strconv.Quote(value)
+	}
+	return value
+}
+
+func (c formatterConfig) truncate(s string) string {
+	const ellipsis = "..."
+
+	if c.maxLength <= 0 || utf8.RuneCountInString(s) <= c.maxLength {
+		return s
+	}
+	if c.maxLength <= len(ellipsis) {
+		return ellipsis[:c.maxLength]
+	}
+
+	runes := 0
+	for i := range s {
+		if runes == c.maxLength-len(ellipsis) {
+			return s[:i] + ellipsis
+		}
+		runes++
+	}
+	return s
+}
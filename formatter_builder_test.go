@@ -0,0 +1,128 @@
+package errors_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/arquivei/errors"
+)
+
+func TestNewFormatter(t *testing.T) {
+	err := errors.With(errors.New("root error"), errors.Op("op1"), errors.KV("b", 2))
+	err = errors.Errorf("wrapped: %w", err)
+	err = errors.With(err,
+		errors.Op("op2"),
+		errors.SeverityInput,
+		errors.Code("BAD_REQUEST"),
+		errors.KV("a", "value 1"),
+	)
+
+	tests := []struct {
+		name string
+		opts []errors.FormatterOption
+		want string
+	}{
+		{
+			name: "default",
+			want: "op2: op1: [input] (BAD_REQUEST) wrapped: root error {a=value 1, b=2}",
+		},
+		{
+			name: "sections",
+			opts: []errors.FormatterOption{
+				errors.FormatSections(errors.SectionCode, errors.SectionRootMessage, errors.SectionOps),
+			},
+			want: "(BAD_REQUEST) root error op2: op1:",
+		},
+		{
+			name: "separators",
+			opts: []errors.FormatterOption{
+				errors.FormatSectionSeparator(" | "),
+				errors.FormatOpSeparator(" > "),
+				errors.FormatKVSeparator("; "),
+				errors.FormatKVAssign(": "),
+			},
+			want: "op2 > op1: | [input] | (BAD_REQUEST) | wrapped: root error | {a: value 1; b: 2}",
+		},
+		{
+			name: "sort keys and quote values",
+			opts: []errors.FormatterOption{
+				errors.FormatSections(errors.SectionKV),
+				errors.FormatSortKeys(),
+				errors.FormatQuoteValues(),
+			},
+			want: `{a="value 1", b="2"}`,
+		},
+		{
+			name: "value renderer",
+			opts: []errors.FormatterOption{
+				errors.FormatSections(errors.SectionKV),
+				errors.FormatValueRenderer("a", func(v any) string {
+					return strings.ToUpper(v.(string))
+				}),
+			},
+			want: "{a=VALUE 1, b=2}",
+		},
+		{
+			name: "group by frame",
+			opts: []errors.FormatterOption{
+				errors.FormatSections(errors.SectionKV),
+				errors.FormatGroupByFrame(),
+			},
+			want: "{op2: a=value 1; op1: b=2}",
+		},
+		{
+			name: "max length",
+			opts: []errors.FormatterOption{errors.FormatMaxLength(12)},
+			want: "op2: op1:...",
+		},
+		{
+			name: "max length shorter than ellipsis",
+			opts: []errors.FormatterOption{errors.FormatMaxLength(2)},
+			want: "..",
+		},
+		{
+			name: "max length not reached",
+			opts: []errors.FormatterOption{
+				errors.FormatSections(errors.SectionMessage),
+				errors.FormatMaxLength(100),
+			},
+			want: "wrapped: root error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := errors.NewFormatter(tt.opts...)(err)
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+
+	t.Run("joined ops", func(t *testing.T) {
+		err := errors.With(errors.Join(
+			errors.With(errors.New("first"), errors.Op("op1")),
+			errors.With(errors.New("second"), errors.Op("op2")),
+		), errors.Op("op3"))
+		formatter := errors.NewFormatter(errors.FormatSections(errors.SectionOps), errors.FormatOpSeparator(" > "))
+		if got := formatter(err); got != "op3 > [op1 | op2]:" {
+			t.Errorf("expected %q, got %q", "op3 > [op1 | op2]:", got)
+		}
+	})
+
+	t.Run("empty sections are omitted", func(t *testing.T) {
+		err := errors.With(errors.New("root error"), errors.NoOp)
+		got := errors.NewFormatter(errors.FormatSectionSeparator(" | "))(err)
+		if got != "root error" {
+			t.Errorf("expected %q, got %q", "root error", got)
+		}
+	})
+
+	t.Run("attached with With", func(t *testing.T) {
+		formatter := errors.NewFormatter(errors.FormatSections(errors.SectionSeverity, errors.SectionMessage))
+		err := errors.With(err, formatter)
+		want := "[input] wrapped: root error"
+		if got := errors.Format(err); got != want {
+			t.Errorf("expected %q, got %q", want, got)
+		}
+	})
+}
@@ -0,0 +1,122 @@
+package errors
+
+// Frame is an Op and the values attached with it, in the same call to With.
+// The values of a frame are the ones added after the previous Op, up to the next one,
+// or to an error wrapping them, like the ones created by fmt.Errorf.
+type Frame struct {
+	// Op is the operation of the frame. It's empty if the values were attached without an Op.
+	Op Op
+	// Severity is the severity attached in the frame, if any.
+	Severity Severity
+	// Code is the code attached in the frame, if any.
+	Code Code
+	// KVs are the key-value pairs attached in the frame, from the most recent to the oldest.
+	// Built-in values, like Op, Severity and Code, are not included.
+	KVs []KeyValuer
+	// Shadowed are the key-value pairs in KVs that are overridden by more recent values
+	// with the same key, so they are not returned by Value.
+	Shadowed []KeyValuer
+}
+
+// Frames returns the frames of err, from the most recent to the oldest.
+// Joined errors are walked depth-first, in pre-order, and values are only shadowed by
+// values in the path from the top of the tree to them.
+func Frames(err error) []Frame {
+	var frames []Frame
+	collectFrames(err, make(map[any]struct{}), &frames)
+	return frames
+}
+
+func collectFrames(err error, seen map[any]struct{}, frames *[]Frame) {
+	for err != nil {
+		var linear []Frame
+		linear, err = linearFrames(err)
+		for _, frame := range linear {
+			frame.shadow(seen)
+			*frames = append(*frames, frame)
+		}
+
+		switch e := err.(type) {
+		case interface{ Unwrap() error }:
+			err = e.Unwrap()
+		case interface{ Unwrap() []error }:
+			for _, child := range e.Unwrap() {
+				childSeen := make(map[any]struct{}, len(seen))
+				for key := range seen {
+					childSeen[key] = struct{}{}
+				}
+				collectFrames(child, childSeen, frames)
+			}
+			return
+		default:
+			return
+		}
+	}
+}
+
+// linearFrames returns the frames of the Errors at the top of err and the first error below them
+// that is not an Error. A frame ends before the next Op. Empty frames are skipped.
+func linearFrames(err error) ([]Frame, error) {
+	var frames []Frame
+	var frame Frame
+	started := false
+
+	for {
+		e, ok := err.(Error)
+		if !ok {
+			break
+		}
+		for _, kv := range e.values() {
+			if kv.Key() == (opKey{}) && started {
+				if !frame.isEmpty() {
+					frames = append(frames, frame)
+				}
+				frame = Frame{}
+			}
+			frame.add(kv)
+			started = true
+		}
+		err = e.err
+	}
+	if !frame.isEmpty() {
+		frames = append(frames, frame)
+	}
+
+	return frames, err
+}
+
+// add adds a value to the frame. Only the most recent severity and code are kept.
+func (f *Frame) add(kv KeyValuer) {
+	switch key, value := kv.Key(), kv.Value(); key {
+	case opKey{}:
+		f.Op, _ = value.(Op)
+	case severityKey{}:
+		if f.Severity == SeverityUnset {
+			f.Severity, _ = value.(Severity)
+		}
+	case codeKey{}:
+		if f.Code == CodeUnset {
+			f.Code, _ = value.(Code)
+		}
+	default:
+		if !isBuiltInKeyValuer(key) {
+			f.KVs = append(f.KVs, kv)
+		}
+	}
+}
+
+// shadow sets the values of the frame whose keys were already seen as shadowed
+// and adds the other keys to seen.
+func (f *Frame) shadow(seen map[any]struct{}) {
+	for _, kv := range f.KVs {
+		if _, exists := seen[kv.Key()]; exists {
+			f.Shadowed = append(f.Shadowed, kv)
+			continue
+		}
+		seen[kv.Key()] = struct{}{}
+	}
+}
+
+func (f Frame) isEmpty() bool {
+	return f.Op == "" && f.Severity == SeverityUnset && f.Code == CodeUnset && len(f.KVs) == 0
+}
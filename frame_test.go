@@ -0,0 +1,68 @@
+package errors
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestFrames(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want []Frame
+	}{
+		{
+			name: "nil error",
+			err:  nil,
+			want: nil,
+		},
+		{
+			name: "root error",
+			err:  New("root error"),
+			want: nil,
+		},
+		{
+			name: "wrapped frames",
+			err: func() error {
+				err := With(New("root error"), Op("op1"), SeverityInput, KV("key1", "value1"), KV("key2", "value2"))
+				err = fmt.Errorf("wrapped: %w", err)
+				err = With(err, Op("op2"), Code("MY_CODE"), KV("key1", "override"))
+				return With(err, NoOp, KV("key3", "value3"))
+			}(),
+			want: []Frame{
+				{KVs: []KeyValuer{KV("key3", "value3")}},
+				{Op: "op2", Code: "MY_CODE", KVs: []KeyValuer{KV("key1", "override")}},
+				{
+					Op:       "op1",
+					Severity: SeverityInput,
+					KVs:      []KeyValuer{KV("key2", "value2"), KV("key1", "value1")},
+					Shadowed: []KeyValuer{KV("key1", "value1")},
+				},
+			},
+		},
+		{
+			name: "joined branches",
+			err: With(
+				Join(
+					With(New("a"), Op("opA"), KV("key", "a")),
+					With(New("b"), Op("opB"), KV("other", "b")),
+				),
+				Op("op"),
+				KV("key", "top"),
+			),
+			want: []Frame{
+				{Op: "op", KVs: []KeyValuer{KV("key", "top")}},
+				{Op: "opA", KVs: []KeyValuer{KV("key", "a")}, Shadowed: []KeyValuer{KV("key", "a")}},
+				{Op: "opB", KVs: []KeyValuer{KV("other", "b")}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Frames(tt.err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %#v, got %#v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package errors
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// Go runs fn in a new goroutine under DontPanic, so a panic is converted into an error
+// instead of crashing the process. The returned channel receives the error returned by fn
+// (or created from the panic), which may be nil, and is then closed.
+func Go[F func() | func() error](fn F) <-chan error {
+	errc := make(chan error, 1)
+	go func() {
+		defer close(errc)
+		errc <- DontPanic(fn)
+	}()
+	return errc
+}
+
+// GroupMode defines how a Group handles errors.
+type GroupMode int
+
+const (
+	// FirstError cancels the group's context on the first error and only that error is returned by Wait.
+	FirstError GroupMode = iota
+	// CollectAll runs all goroutines to completion and all errors are returned by Wait.
+	CollectAll
+)
+
+type goroutineKey struct{}
+
+func (goroutineKey) String() string {
+	return "goroutine"
+}
+
+// GetGoroutine returns the goroutine that returned err in a Group: its index, as an int,
+// or its name, as a string, if started with GoNamed. It returns nil if err was not returned by a Group.
+func GetGoroutine(err error) any {
+	return Value(err, goroutineKey{})
+}
+
+// Group runs goroutines under DontPanic and collects their errors, like errgroup.Group.
+// Each error is annotated with the index of the goroutine that returned it (in the order Go was called)
+// or with its name, if started with GoNamed. Use GetGoroutine to read it.
+//
+// A Group must be created with NewGroup.
+type Group struct {
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+	mode   GroupMode
+
+	wg  sync.WaitGroup
+	sem chan struct{}
+
+	mu     sync.Mutex
+	next   int
+	errs   []indexedError
+	failed bool
+}
+
+type indexedError struct {
+	index int
+	err   error
+}
+
+// NewGroup creates a Group and the context passed to its goroutines.
+// The context is canceled when Wait returns or, in FirstError mode, when the first error occurs.
+func NewGroup(ctx context.Context, mode GroupMode) (*Group, context.Context) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	return &Group{
+		ctx:    ctx,
+		cancel: cancel,
+		mode:   mode,
+	}, ctx
+}
+
+// SetLimit limits the number of goroutines running at the same time to n.
+// A negative value means no limit. It must not be called while goroutines are running.
+func (g *Group) SetLimit(n int) {
+	if n < 0 {
+		g.sem = nil
+		return
+	}
+	g.sem = make(chan struct{}, n)
+}
+
+// Go runs fn in a new goroutine. If the group has a limit, Go blocks until fn can be started.
+// The error returned by fn, or created from a panic, is annotated with the goroutine's index.
+func (g *Group) Go(fn func(ctx context.Context) error) {
+	g.start(nil, fn)
+}
+
+// GoNamed is like Go, but the error is annotated with the given name instead of the goroutine's index.
+func (g *Group) GoNamed(name string, fn func(ctx context.Context) error) {
+	g.start(name, fn)
+}
+
+func (g *Group) start(name any, fn func(ctx context.Context) error) {
+	if g.sem != nil {
+		g.sem <- struct{}{}
+	}
+
+	g.mu.Lock()
+	index := g.next
+	g.next++
+	g.mu.Unlock()
+
+	if name == nil {
+		name = index
+	}
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		if g.sem != nil {
+			defer func() { <-g.sem }()
+		}
+
+		err := DontPanic(func() error {
+			return fn(g.ctx)
+		})
+		if err != nil {
+			g.addError(index, With(err, NoOp, KV(goroutineKey{}, name)))
+		}
+	}()
+}
+
+func (g *Group) addError(index int, err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.mode == FirstError {
+		if g.failed {
+			return
+		}
+		g.failed = true
+		g.cancel(err)
+	}
+	g.errs = append(g.errs, indexedError{index: index, err: err})
+}
+
+// Wait blocks until all goroutines return and returns their errors joined by errors.Join,
+// sorted by the goroutines' indexes. In FirstError mode, only the first error is included.
+// It returns nil if no goroutine failed.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	g.cancel(nil)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	sort.Slice(g.errs, func(i, j int) bool {
+		return g.errs[i].index < g.errs[j].index
+	})
+	errs := make([]error, 0, len(g.errs))
+	for _, e := range g.errs {
+		errs = append(errs, e.err)
+	}
+
+	return Join(errs...)
+}
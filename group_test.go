@@ -0,0 +1,137 @@
+package errors_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/arquivei/errors"
+)
+
+func TestGo(t *testing.T) {
+	if err := <-errors.Go(func() {}); err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+
+	rootErr := errors.New("some error")
+	if err := <-errors.Go(func() error { return rootErr }); err != rootErr {
+		t.Errorf("expected %v, got %v", rootErr, err)
+	}
+
+	err := <-errors.Go(func() { panic("goroutine panic") })
+	if errors.GetCode(err) != errors.CodePanic {
+		t.Errorf("expected code %s, got %s", errors.CodePanic, errors.GetCode(err))
+	}
+	if err.Error() != "panic: goroutine panic" {
+		t.Errorf("expected 'panic: goroutine panic', got '%s'", err.Error())
+	}
+}
+
+func TestGroupCollectAll(t *testing.T) {
+	g, ctx := errors.NewGroup(context.Background(), errors.CollectAll)
+
+	g.Go(func(ctx context.Context) error {
+		time.Sleep(10 * time.Millisecond)
+		return errors.With(errors.New("first"), errors.Op("op1"))
+	})
+	g.Go(func(ctx context.Context) error { return nil })
+	g.GoNamed("worker", func(ctx context.Context) error {
+		panic("worker panic")
+	})
+
+	err := g.Wait()
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if ctx.Err() == nil {
+		t.Error("expected context to be canceled after Wait")
+	}
+
+	roots := errors.GetRootErrors(err)
+	if len(roots) != 2 {
+		t.Fatalf("expected 2 errors, got %v", roots)
+	}
+
+	children := err.(interface{ Unwrap() []error }).Unwrap()
+	if got := errors.Format(children[0]); got != "op1: first {goroutine=0}" {
+		t.Errorf("expected 'op1: first {goroutine=0}', got '%s'", got)
+	}
+	if got := errors.Format(errors.With(children[1], errors.NoOp, errors.KVFormatter)); got != "panic: worker panic {goroutine=worker}" {
+		t.Errorf("expected 'panic: worker panic {goroutine=worker}', got '%s'", got)
+	}
+	if goroutine := errors.GetGoroutine(children[0]); goroutine != 0 {
+		t.Errorf("expected goroutine 0, got %v", goroutine)
+	}
+	if goroutine := errors.GetGoroutine(children[1]); goroutine != "worker" {
+		t.Errorf("expected goroutine worker, got %v", goroutine)
+	}
+	if goroutine := errors.GetGoroutine(errors.New("other")); goroutine != nil {
+		t.Errorf("expected no goroutine, got %v", goroutine)
+	}
+	if code := errors.GetCode(children[1]); code != errors.CodePanic {
+		t.Errorf("expected code %s, got %s", errors.CodePanic, code)
+	}
+}
+
+func TestGroupFirstError(t *testing.T) {
+	g, ctx := errors.NewGroup(context.Background(), errors.FirstError)
+
+	firstErr := errors.New("first")
+	g.Go(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	g.Go(func(ctx context.Context) error {
+		return firstErr
+	})
+
+	err := g.Wait()
+	if !errors.Is(err, firstErr) {
+		t.Fatalf("expected first error, got %v", err)
+	}
+	if errors.Is(err, context.Canceled) {
+		t.Errorf("expected only the first error, got %v", err)
+	}
+	if cause := context.Cause(ctx); !errors.Is(cause, firstErr) {
+		t.Errorf("expected context cause to be the first error, got %v", cause)
+	}
+}
+
+func TestGroupNoErrors(t *testing.T) {
+	g, _ := errors.NewGroup(context.Background(), errors.FirstError)
+	for range 3 {
+		g.Go(func(ctx context.Context) error { return nil })
+	}
+	if err := g.Wait(); err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+}
+
+func TestGroupSetLimit(t *testing.T) {
+	g, _ := errors.NewGroup(context.Background(), errors.CollectAll)
+	g.SetLimit(2)
+
+	var running, maxRunning atomic.Int32
+	for range 10 {
+		g.Go(func(ctx context.Context) error {
+			n := running.Add(1)
+			defer running.Add(-1)
+			for {
+				current := maxRunning.Load()
+				if n <= current || maxRunning.CompareAndSwap(current, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+	if maxRunning.Load() > 2 {
+		t.Errorf("expected at most 2 goroutines running, got %d", maxRunning.Load())
+	}
+}
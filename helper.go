@@ -0,0 +1,81 @@
+package errors
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// maxHelperDepth is the maximum number of frames inspected when skipping helpers.
+const maxHelperDepth = 32
+
+var (
+	helperPCs   sync.Map // pc -> struct{}, the call sites of Helper that were already registered
+	helperFuncs sync.Map // function name -> struct{}
+	hasHelpers  atomic.Bool
+)
+
+// Helper marks the calling function as a helper, like testing.T.Helper. Helpers are skipped
+// when the automatic Op is determined, so errors created or wrapped by them get the Op of
+// the function calling the helper:
+//
+//	func wrapDB(err error) error {
+//		errors.Helper()
+//		return errors.With(err, errors.Code("DB_ERROR"))
+//	}
+//
+// Once a function is marked, it is a helper for the lifetime of the program. Calling Helper
+// again from the same function is cheap.
+func Helper() {
+	var pcs [1]uintptr
+	if runtime.Callers(2, pcs[:]) == 0 {
+		return
+	}
+	if _, ok := helperPCs.Load(pcs[0]); ok {
+		return
+	}
+
+	frame, _ := runtime.CallersFrames(pcs[:]).Next()
+	helperFuncs.Store(frame.Function, struct{}{})
+	helperPCs.Store(pcs[0], struct{}{})
+	hasHelpers.Store(true)
+}
+
+// WithDepth is like With, but the automatic Op and the stack trace skip the given number of
+// additional stack frames. A skip of 0 is the same as calling With, 1 identifies the caller
+// of the function calling WithDepth, and so on.
+func WithDepth(skip int, err error, keyvalues ...KeyValuer) error {
+	return with(skip+1, err, keyvalues...)
+}
+
+// isHelper reports whether the function was marked by Helper.
+func isHelper(function string) bool {
+	_, ok := helperFuncs.Load(function)
+	return ok
+}
+
+// isHelperPC reports whether the innermost function at pc was marked by Helper.
+func isHelperPC(pc uintptr) bool {
+	f := runtime.FuncForPC(pc)
+	return f != nil && isHelper(f.Name())
+}
+
+// helperCallerOp returns the Op of the first caller that is not a helper. The argument skip
+// is the number of stack frames to skip, with 0 identifying the caller of helperCallerOp.
+//
+// The frames are resolved with runtime.CallersFrames, so helpers inlined into their callers are
+// also skipped. The Ops are not cached, as inlined functions share program counters with their callers.
+func helperCallerOp(skip int) Op {
+	var pcs [maxHelperDepth]uintptr
+	n := runtime.Callers(skip+2, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if frame.Function != "" && !isHelper(frame.Function) {
+			return frameOp(opNamingState.Load().namer, frame, false)
+		}
+		if !more {
+			return opUnknownFunction
+		}
+	}
+}
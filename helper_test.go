@@ -0,0 +1,82 @@
+package errors
+
+import "testing"
+
+//go:noinline
+func wrapWithHelper(err error) error {
+	Helper()
+	return With(err, KV("helper", true))
+}
+
+func wrapWithInlinedHelper(err error) error {
+	Helper()
+	return With(err)
+}
+
+//go:noinline
+func wrapWithNestedHelper(err error) error {
+	Helper()
+	return wrapWithHelper(err)
+}
+
+//go:noinline
+func wrapWithDepth(err error) error {
+	return WithDepth(1, err)
+}
+
+//go:noinline
+func notAHelper(err error) error {
+	return With(err)
+}
+
+func TestHelper(t *testing.T) {
+	tests := []struct {
+		name string
+		wrap func(error) error
+	}{
+		{name: "helper", wrap: wrapWithHelper},
+		{name: "inlined helper", wrap: wrapWithInlinedHelper},
+		{name: "nested helpers", wrap: wrapWithNestedHelper},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := callHelper(tt.wrap)
+			if got := GetOpStack(err); got != "errors.callHelper" {
+				t.Errorf("expected op %q, got %q", "errors.callHelper", got)
+			}
+		})
+	}
+
+	t.Run("not a helper", func(t *testing.T) {
+		err := callHelper(notAHelper)
+		if got := GetOpStack(err); got != "errors.notAHelper" {
+			t.Errorf("expected op %q, got %q", "errors.notAHelper", got)
+		}
+	})
+}
+
+//go:noinline
+func callHelper(wrap func(error) error) error {
+	return wrap(New("root error"))
+}
+
+func TestWithDepth(t *testing.T) {
+	err := callHelper(wrapWithDepth)
+	if got := GetOpStack(err); got != "errors.callHelper" {
+		t.Errorf("expected op %q, got %q", "errors.callHelper", got)
+	}
+
+	err = WithDepth(0, New("root error"), KV("key", "value"))
+	if got := GetOpStack(err); got != "errors.TestWithDepth" {
+		t.Errorf("expected op %q, got %q", "errors.TestWithDepth", got)
+	}
+}
+
+func BenchmarkWithHelper(b *testing.B) {
+	rootErr := New("root error")
+
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = wrapWithHelper(rootErr)
+	}
+}
@@ -0,0 +1,82 @@
+// Package httperr maps errors to HTTP status codes and RFC 9457 problem details responses.
+package httperr
+
+import (
+	"net/http"
+
+	"github.com/arquivei/errors"
+)
+
+// DefaultMapper is the Mapper used by the package level functions.
+var DefaultMapper = NewMapper()
+
+// Mapper maps errors to HTTP status codes and problem details.
+type Mapper struct {
+	// Codes maps error codes to status codes. It takes precedence over the Registry and Severities.
+	Codes map[errors.Code]int
+	// Registry provides the HTTP status, public message and documentation URL of registered codes.
+	// It takes precedence over Severities. It can be nil.
+	Registry *errors.Registry
+	// Severities maps error severities to status codes.
+	Severities map[errors.Severity]int
+	// DefaultStatus is used when neither the code nor the severity of the error are mapped.
+	DefaultStatus int
+	// Types maps error codes to the URI used as the "type" member of problem details.
+	// Codes registered with a documentation URL use it as type. Other codes use "about:blank".
+	Types map[errors.Code]string
+	// ExtensionKeys lists the keys whose values are written as extension members of problem details.
+	// Other key-value pairs are never written, so internal information is not leaked to clients.
+	ExtensionKeys []any
+}
+
+// NewMapper returns a Mapper with the default severity mapping:
+//   - SeverityInput: 400 Bad Request
+//   - SeverityRuntime: 503 Service Unavailable
+//   - SeverityFatal: 500 Internal Server Error
+//
+// Any other error is mapped to 500 Internal Server Error.
+// Codes registered in the errors.DefaultRegistry are mapped to their HTTP status.
+func NewMapper() *Mapper {
+	return &Mapper{
+		Codes:    make(map[errors.Code]int),
+		Registry: errors.DefaultRegistry,
+		Severities: map[errors.Severity]int{
+			errors.SeverityInput:   http.StatusBadRequest,
+			errors.SeverityRuntime: http.StatusServiceUnavailable,
+			errors.SeverityFatal:   http.StatusInternalServerError,
+		},
+		DefaultStatus: http.StatusInternalServerError,
+		Types:         make(map[errors.Code]string),
+	}
+}
+
+// StatusCode returns the HTTP status code for err using the DefaultMapper.
+func StatusCode(err error) int {
+	return DefaultMapper.StatusCode(err)
+}
+
+// StatusCode returns the HTTP status code for err.
+// It returns 200 OK if err is nil.
+func (m *Mapper) StatusCode(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	code := errors.GetCode(err)
+	if status, ok := m.Codes[code]; ok {
+		return status
+	}
+	if info, ok := m.lookup(code); ok && info.HTTPStatus != 0 {
+		return info.HTTPStatus
+	}
+	if status, ok := m.Severities[errors.GetSeverity(err)]; ok {
+		return status
+	}
+	return m.DefaultStatus
+}
+
+func (m *Mapper) lookup(code errors.Code) (errors.CodeInfo, bool) {
+	if m.Registry == nil || code == errors.CodeUnset {
+		return errors.CodeInfo{}, false
+	}
+	return m.Registry.Lookup(code)
+}
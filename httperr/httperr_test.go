@@ -0,0 +1,59 @@
+package httperr_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/arquivei/errors"
+	"github.com/arquivei/errors/httperr"
+)
+
+func TestStatusCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil error", nil, http.StatusOK},
+		{"no severity", errors.New("some error"), http.StatusInternalServerError},
+		{"input", errors.With(errors.New("some error"), errors.SeverityInput), http.StatusBadRequest},
+		{"runtime", errors.With(errors.New("some error"), errors.SeverityRuntime), http.StatusServiceUnavailable},
+		{"fatal", errors.With(errors.New("some error"), errors.SeverityFatal), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := httperr.StatusCode(tt.err); got != tt.want {
+				t.Errorf("expected %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestMapperStatusCode(t *testing.T) {
+	mapper := httperr.NewMapper()
+	mapper.Codes["NOT_FOUND"] = http.StatusNotFound
+	mapper.Severities[errors.SeverityRuntime] = http.StatusBadGateway
+	mapper.DefaultStatus = http.StatusTeapot
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"code override", errors.With(errors.New("some error"), errors.SeverityInput, errors.Code("NOT_FOUND")), http.StatusNotFound},
+		{"unmapped code", errors.With(errors.New("some error"), errors.SeverityInput, errors.Code("OTHER")), http.StatusBadRequest},
+		{"custom severity", errors.With(errors.New("some error"), errors.SeverityRuntime), http.StatusBadGateway},
+		{"default status", errors.New("some error"), http.StatusTeapot},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mapper.StatusCode(tt.err); got != tt.want {
+				t.Errorf("expected %d, got %d", tt.want, got)
+			}
+		})
+	}
+
+	if got := httperr.StatusCode(errors.With(errors.New("some error"), errors.Code("NOT_FOUND"))); got != http.StatusInternalServerError {
+		t.Errorf("expected DefaultMapper to be unchanged, got %d", got)
+	}
+}
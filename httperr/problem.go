@@ -0,0 +1,257 @@
+package httperr
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/arquivei/errors"
+)
+
+// ContentTypeProblem is the media type of problem details responses.
+const ContentTypeProblem = "application/problem+json"
+
+// maxProblemSize limits how much of a response body ParseProblem reads.
+const maxProblemSize = 1 << 20
+
+var (
+	_ errors.KeyValuer = Status(0)
+
+	// ErrUnexpectedStatus is the root error returned by ParseProblem when the
+	// response is not a problem details response.
+	ErrUnexpectedStatus = errors.New("unexpected status")
+)
+
+// Problem is a RFC 9457 problem details object. Besides the standard members,
+// the error code and severity are written as the "code" and "severity" extension members.
+type Problem struct {
+	Type       string
+	Title      string
+	Status     int
+	Detail     string
+	Instance   string
+	Code       errors.Code
+	Severity   errors.Severity
+	Extensions map[string]any
+}
+
+// MarshalJSON encodes the problem with its extension members at the top level.
+func (p Problem) MarshalJSON() ([]byte, error) {
+	m := make(map[string]any, len(p.Extensions)+7)
+	for k, v := range p.Extensions {
+		m[k] = v
+	}
+	setIfNotEmpty(m, "type", p.Type)
+	setIfNotEmpty(m, "title", p.Title)
+	setIfNotEmpty(m, "detail", p.Detail)
+	setIfNotEmpty(m, "instance", p.Instance)
+	setIfNotEmpty(m, "code", string(p.Code))
+	setIfNotEmpty(m, "severity", string(p.Severity))
+	if p.Status != 0 {
+		m["status"] = p.Status
+	}
+	return json.Marshal(m)
+}
+
+// UnmarshalJSON decodes the problem, keeping unknown members as extensions.
+func (p *Problem) UnmarshalJSON(data []byte) error {
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+
+	*p = Problem{
+		Type:     popString(m, "type"),
+		Title:    popString(m, "title"),
+		Detail:   popString(m, "detail"),
+		Instance: popString(m, "instance"),
+		Code:     errors.Code(popString(m, "code")),
+		Severity: errors.Severity(popString(m, "severity")),
+	}
+	if status, ok := m["status"].(float64); ok {
+		p.Status = int(status)
+	}
+	delete(m, "status")
+	if len(m) > 0 {
+		p.Extensions = m
+	}
+	return nil
+}
+
+func setIfNotEmpty(m map[string]any, key, value string) {
+	if value != "" {
+		m[key] = value
+	}
+}
+
+func popString(m map[string]any, key string) string {
+	s, _ := m[key].(string)
+	delete(m, key)
+	return s
+}
+
+// NewProblem builds the problem details for err using the DefaultMapper.
+func NewProblem(err error) Problem {
+	return DefaultMapper.Problem(err)
+}
+
+// Problem builds the problem details for err.
+// The detail is the public message of the error code, if registered. Otherwise, the error
+// message is only written as the detail of client errors (4xx), so server errors don't leak
+// internal information.
+// If err is nil, the problem only has the 200 OK status.
+func (m *Mapper) Problem(err error) Problem {
+	status := m.StatusCode(err)
+	if err == nil {
+		return Problem{Type: "about:blank", Title: http.StatusText(status), Status: status}
+	}
+	code := errors.GetCode(err)
+
+	problem := Problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Code:     code,
+		Severity: errors.GetSeverity(err),
+	}
+	info, registered := m.lookup(code)
+	if t, ok := m.Types[code]; ok {
+		problem.Type = t
+	} else if registered && info.DocURL != "" {
+		problem.Type = info.DocURL
+	}
+	if registered && info.PublicMessage != "" {
+		problem.Detail = info.PublicMessage
+	} else if status < http.StatusInternalServerError {
+		problem.Detail = err.Error()
+	}
+
+	for _, key := range m.ExtensionKeys {
+		if value := errors.Value(err, key); value != nil {
+			if problem.Extensions == nil {
+				problem.Extensions = make(map[string]any, len(m.ExtensionKeys))
+			}
+			problem.Extensions[fmt.Sprint(key)] = value
+		}
+	}
+
+	return problem
+}
+
+// WriteProblem writes err as a problem details response using the DefaultMapper.
+// Nothing is written if err is nil.
+func WriteProblem(w http.ResponseWriter, err error) {
+	DefaultMapper.WriteProblem(w, err)
+}
+
+// WriteProblem writes err as a problem details response.
+// Nothing is written if err is nil.
+func (m *Mapper) WriteProblem(w http.ResponseWriter, err error) {
+	if err == nil {
+		return
+	}
+	problem := m.Problem(err)
+
+	w.Header().Set("Content-Type", ContentTypeProblem)
+	w.WriteHeader(problem.Status)
+	_ = json.NewEncoder(w).Encode(problem)
+}
+
+// ParseProblem rebuilds an error from a problem details response.
+// It returns nil if the response status is not an error (4xx or 5xx).
+//
+// The returned error has the problem's detail (or title) as message and carries
+// its code, severity, status (see GetStatus) and extension members as key-value pairs.
+// If the problem has no severity, it's derived from the status code.
+// If the response is not a problem details response, the error is ErrUnexpectedStatus
+// with the status code.
+func ParseProblem(resp *http.Response) error {
+	if resp.StatusCode < http.StatusBadRequest {
+		return nil
+	}
+
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType != ContentTypeProblem {
+		return errors.With(ErrUnexpectedStatus, severityFromStatus(resp.StatusCode), Status(resp.StatusCode))
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxProblemSize))
+	if err != nil {
+		return errors.With(err, errors.SeverityRuntime, Status(resp.StatusCode))
+	}
+
+	var problem Problem
+	if err := json.Unmarshal(body, &problem); err != nil {
+		return errors.With(err, errors.SeverityRuntime, Status(resp.StatusCode))
+	}
+	if problem.Status == 0 {
+		problem.Status = resp.StatusCode
+	}
+	if problem.Title == "" {
+		problem.Title = http.StatusText(problem.Status)
+	}
+
+	return problem.Err()
+}
+
+// Err returns the problem as an error.
+func (p Problem) Err() error {
+	msg := p.Detail
+	if msg == "" {
+		msg = p.Title
+	}
+
+	severity := p.Severity
+	if severity == errors.SeverityUnset {
+		severity = severityFromStatus(p.Status)
+	}
+
+	kvs := make([]errors.KeyValuer, 0, len(p.Extensions)+4)
+	kvs = append(kvs, errors.NoOp)
+	for k, v := range p.Extensions {
+		kvs = append(kvs, errors.KV(k, v))
+	}
+	kvs = append(kvs, severity, Status(p.Status))
+	if p.Code != errors.CodeUnset {
+		kvs = append(kvs, p.Code)
+	}
+
+	return errors.With(errors.New(msg), kvs...)
+}
+
+func severityFromStatus(status int) errors.Severity {
+	switch status {
+	case http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return errors.SeverityRuntime
+	}
+	if status < http.StatusInternalServerError {
+		return errors.SeverityInput
+	}
+	return errors.SeverityFatal
+}
+
+// Status is the HTTP status code of an error received from a problem details response.
+type Status int
+
+type statusKey struct{}
+
+func (statusKey) String() string {
+	return "status"
+}
+
+func (s Status) Key() any {
+	return statusKey{}
+}
+
+func (s Status) Value() any {
+	return s
+}
+
+// GetStatus returns the HTTP status code attached by ParseProblem, or 0 if there isn't one.
+func GetStatus(err error) int {
+	return int(errors.ValueT[Status](err, statusKey{}))
+}
@@ -0,0 +1,184 @@
+package httperr_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/arquivei/errors"
+	"github.com/arquivei/errors/httperr"
+)
+
+func TestWriteProblem(t *testing.T) {
+	mapper := httperr.NewMapper()
+	mapper.Codes["NOT_FOUND"] = http.StatusNotFound
+	mapper.Types["NOT_FOUND"] = "https://example.com/problems/not-found"
+	mapper.ExtensionKeys = []any{"id", "missing"}
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		want       string
+	}{
+		{
+			name: "client error",
+			err: errors.With(
+				errors.New("user not found"),
+				errors.SeverityInput,
+				errors.Code("NOT_FOUND"),
+				errors.KV("id", 42),
+				errors.KV("secret", "not exposed"),
+			),
+			wantStatus: http.StatusNotFound,
+			want:       `{"code":"NOT_FOUND","detail":"user not found","id":42,"severity":"input","status":404,"title":"Not Found","type":"https://example.com/problems/not-found"}`,
+		},
+		{
+			name:       "server error",
+			err:        errors.With(errors.New("database password is wrong"), errors.SeverityFatal),
+			wantStatus: http.StatusInternalServerError,
+			want:       `{"severity":"fatal","status":500,"title":"Internal Server Error","type":"about:blank"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			mapper.WriteProblem(rec, tt.err)
+
+			if ct := rec.Header().Get("Content-Type"); ct != httperr.ContentTypeProblem {
+				t.Errorf("expected content type %s, got %s", httperr.ContentTypeProblem, ct)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+			if got := strings.TrimSpace(rec.Body.String()); got != tt.want {
+				t.Errorf("expected '%s', got '%s'", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestWriteProblemNil(t *testing.T) {
+	if problem := httperr.NewProblem(nil); problem.Status != http.StatusOK || problem.Detail != "" {
+		t.Errorf("expected empty 200 problem, got %+v", problem)
+	}
+
+	rec := httptest.NewRecorder()
+	httperr.WriteProblem(rec, nil)
+	if rec.Body.Len() != 0 || rec.Header().Get("Content-Type") != "" {
+		t.Errorf("expected nothing to be written, got %q", rec.Body.String())
+	}
+}
+
+func TestParseProblem(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		mapper := httperr.NewMapper()
+		mapper.Codes["NOT_FOUND"] = http.StatusNotFound
+		mapper.ExtensionKeys = []any{"id"}
+		mapper.WriteProblem(rec, errors.With(
+			errors.New("user not found"),
+			errors.SeverityInput,
+			errors.Code("NOT_FOUND"),
+			errors.KV("id", "abc"),
+		))
+
+		err := httperr.ParseProblem(rec.Result())
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if err.Error() != "user not found" {
+			t.Errorf("expected 'user not found', got '%s'", err.Error())
+		}
+		if code := errors.GetCode(err); code != "NOT_FOUND" {
+			t.Errorf("expected code NOT_FOUND, got %s", code)
+		}
+		if severity := errors.GetSeverity(err); severity != errors.SeverityInput {
+			t.Errorf("expected severity input, got %s", severity)
+		}
+		if status := httperr.GetStatus(err); status != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", status)
+		}
+		if id := errors.ValueT[string](err, "id"); id != "abc" {
+			t.Errorf("expected id abc, got %s", id)
+		}
+	})
+
+	t.Run("problem without severity", func(t *testing.T) {
+		resp := newResponse(http.StatusServiceUnavailable, httperr.ContentTypeProblem, `{"title":"Service Unavailable"}`)
+
+		err := httperr.ParseProblem(resp)
+		if err.Error() != "Service Unavailable" {
+			t.Errorf("expected 'Service Unavailable', got '%s'", err.Error())
+		}
+		if severity := errors.GetSeverity(err); severity != errors.SeverityRuntime {
+			t.Errorf("expected severity runtime, got %s", severity)
+		}
+		if status := httperr.GetStatus(err); status != http.StatusServiceUnavailable {
+			t.Errorf("expected status 503, got %d", status)
+		}
+	})
+
+	t.Run("not a problem", func(t *testing.T) {
+		resp := newResponse(http.StatusBadRequest, "text/plain", "bad request")
+
+		err := httperr.ParseProblem(resp)
+		if !errors.Is(err, httperr.ErrUnexpectedStatus) {
+			t.Errorf("expected ErrUnexpectedStatus, got %v", err)
+		}
+		if severity := errors.GetSeverity(err); severity != errors.SeverityInput {
+			t.Errorf("expected severity input, got %s", severity)
+		}
+	})
+
+	t.Run("invalid problem", func(t *testing.T) {
+		resp := newResponse(http.StatusInternalServerError, httperr.ContentTypeProblem, `{`)
+
+		err := httperr.ParseProblem(resp)
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if status := httperr.GetStatus(err); status != http.StatusInternalServerError {
+			t.Errorf("expected status 500, got %d", status)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		if err := httperr.ParseProblem(newResponse(http.StatusOK, "text/plain", "ok")); err != nil {
+			t.Errorf("expected nil, got %v", err)
+		}
+	})
+}
+
+func newResponse(status int, contentType, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{contentType}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestWriteProblemRegisteredCode(t *testing.T) {
+	registry := errors.NewRegistry()
+	registry.Register(errors.CodeInfo{
+		Code:          "QUOTA_EXCEEDED",
+		HTTPStatus:    http.StatusTooManyRequests,
+		PublicMessage: "You have exceeded your quota.",
+		DocURL:        "https://example.com/problems/quota",
+	})
+	mapper := httperr.NewMapper()
+	mapper.Registry = registry
+
+	rec := httptest.NewRecorder()
+	mapper.WriteProblem(rec, errors.With(errors.New("quota of user 42 exceeded"), errors.Code("QUOTA_EXCEEDED")))
+
+	if rec.Code != http.StatusTooManyRequests {
+		t.Errorf("expected status 429, got %d", rec.Code)
+	}
+	expected := `{"code":"QUOTA_EXCEEDED","detail":"You have exceeded your quota.","status":429,"title":"Too Many Requests","type":"https://example.com/problems/quota"}`
+	if got := strings.TrimSpace(rec.Body.String()); got != expected {
+		t.Errorf("expected '%s', got '%s'", expected, got)
+	}
+}
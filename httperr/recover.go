@@ -0,0 +1,115 @@
+package httperr
+
+import (
+	"bufio"
+	"log/slog"
+	"net"
+	"net/http"
+
+	"github.com/arquivei/errors"
+)
+
+var (
+	// RequestIDHeader is the request header whose value is attached to recovered panics as "request_id".
+	RequestIDHeader = "X-Request-Id"
+
+	// DefaultReporter logs recovered panics with slog.Default.
+	DefaultReporter Reporter = func(r *http.Request, err error) {
+		slog.ErrorContext(r.Context(), "panic recovered", "error", err)
+	}
+)
+
+// Reporter receives the errors created from panics recovered by Recover.
+type Reporter func(r *http.Request, err error)
+
+// Recover is a middleware that recovers panics in the next handler using errors.DefaultRecoverPolicy.
+// The panic is converted into an error with errors.CodePanic and the request's method, path and
+// request ID (see RequestIDHeader) as key-value pairs, and handed to reporter.
+// If reporter is nil, DefaultReporter is used.
+//
+// If the handler didn't write the response headers yet, the error is written as a problem details
+// response using the DefaultMapper, which is a 500 Internal Server Error.
+//
+// Panics with http.ErrAbortHandler are not recovered, as net/http uses them to abort a response.
+func Recover(next http.Handler, reporter Reporter) http.Handler {
+	if reporter == nil {
+		reporter = DefaultReporter
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rw := &recoverResponseWriter{ResponseWriter: w}
+
+		err := errors.DontPanicWithPolicy(recoverPolicy(), func() {
+			next.ServeHTTP(rw, r)
+		})
+		if err == nil {
+			return
+		}
+
+		err = errors.With(err,
+			errors.NoOp,
+			errors.KV("method", r.Method),
+			errors.KV("path", r.URL.Path),
+			errors.KV("request_id", r.Header.Get(RequestIDHeader)),
+		)
+		reporter(r, err)
+
+		if !rw.wroteHeader {
+			WriteProblem(w, err)
+		}
+	})
+}
+
+// recoverPolicy returns the errors.DefaultRecoverPolicy, also re-panicking http.ErrAbortHandler.
+func recoverPolicy() errors.RecoverPolicy {
+	policy := errors.DefaultRecoverPolicy
+	repanic := policy.Repanic
+	isAbort := errors.RepanicOnErrors(http.ErrAbortHandler)
+	policy.Repanic = func(v any) bool {
+		return isAbort(v) || (repanic != nil && repanic(v))
+	}
+	return policy
+}
+
+// recoverResponseWriter records whether the response headers were written.
+// It implements http.Flusher and http.Hijacker, forwarding to the original writer,
+// so streaming and websocket handlers keep working behind Recover.
+type recoverResponseWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+func (w *recoverResponseWriter) WriteHeader(statusCode int) {
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *recoverResponseWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush sends the buffered data to the client, if the original writer is an http.Flusher.
+// Otherwise, it does nothing.
+func (w *recoverResponseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		w.wroteHeader = true
+		f.Flush()
+	}
+}
+
+// Hijack takes over the connection, if the original writer is an http.Hijacker.
+// Otherwise, it returns http.ErrNotSupported.
+func (w *recoverResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+	w.wroteHeader = true
+	return h.Hijack()
+}
+
+// Unwrap returns the original http.ResponseWriter, so http.ResponseController can use it.
+func (w *recoverResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,160 @@
+package httperr_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/arquivei/errors"
+	"github.com/arquivei/errors/httperr"
+)
+
+func TestRecover(t *testing.T) {
+	t.Run("panic before writing", func(t *testing.T) {
+		var reported error
+		handler := httperr.Recover(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic("handler panic")
+		}), func(r *http.Request, err error) {
+			reported = err
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/users/42", nil)
+		req.Header.Set("X-Request-Id", "req-1")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusInternalServerError {
+			t.Errorf("expected status 500, got %d", rec.Code)
+		}
+		expected := `{"code":"PANIC","detail":"Internal error.","severity":"fatal","status":500,"title":"Internal Server Error","type":"about:blank"}`
+		if got := strings.TrimSpace(rec.Body.String()); got != expected {
+			t.Errorf("expected '%s', got '%s'", expected, got)
+		}
+
+		if reported == nil {
+			t.Fatal("expected error to be reported")
+		}
+		if code := errors.GetCode(reported); code != errors.CodePanic {
+			t.Errorf("expected code %s, got %s", errors.CodePanic, code)
+		}
+		for key, want := range map[string]string{"method": "POST", "path": "/users/42", "request_id": "req-1"} {
+			if got := errors.ValueT[string](reported, key); got != want {
+				t.Errorf("expected %s=%s, got %s", key, want, got)
+			}
+		}
+	})
+
+	t.Run("panic after writing", func(t *testing.T) {
+		reported := false
+		handler := httperr.Recover(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte("partial"))
+			panic("handler panic")
+		}), func(r *http.Request, err error) {
+			reported = true
+		})
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if !reported {
+			t.Error("expected error to be reported")
+		}
+		if rec.Code != http.StatusAccepted {
+			t.Errorf("expected status 202, got %d", rec.Code)
+		}
+		if rec.Body.String() != "partial" {
+			t.Errorf("expected body 'partial', got '%s'", rec.Body.String())
+		}
+	})
+
+	t.Run("no panic", func(t *testing.T) {
+		handler := httperr.Recover(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("ok"))
+		}), func(r *http.Request, err error) {
+			t.Errorf("unexpected report: %v", err)
+		})
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if rec.Code != http.StatusOK || rec.Body.String() != "ok" {
+			t.Errorf("unexpected response %d '%s'", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("flush", func(t *testing.T) {
+		handler := httperr.Recover(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			f, ok := w.(http.Flusher)
+			if !ok {
+				t.Fatal("expected writer to be an http.Flusher")
+			}
+			_, _ = w.Write([]byte("event"))
+			f.Flush()
+		}), nil)
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if !rec.Flushed {
+			t.Error("expected response to be flushed")
+		}
+	})
+
+	t.Run("flush not supported", func(t *testing.T) {
+		handler := httperr.Recover(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.(http.Flusher).Flush()
+			panic("handler panic")
+		}), func(r *http.Request, err error) {})
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(plainResponseWriter{rec}, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if rec.Code != http.StatusInternalServerError {
+			t.Errorf("expected status 500, got %d", rec.Code)
+		}
+	})
+
+	t.Run("hijack not supported", func(t *testing.T) {
+		handler := httperr.Recover(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			h, ok := w.(http.Hijacker)
+			if !ok {
+				t.Fatal("expected writer to be an http.Hijacker")
+			}
+			if _, _, err := h.Hijack(); !errors.Is(err, http.ErrNotSupported) {
+				t.Errorf("expected http.ErrNotSupported, got %v", err)
+			}
+		}), nil)
+
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	})
+
+	t.Run("abort handler", func(t *testing.T) {
+		handler := httperr.Recover(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic(http.ErrAbortHandler)
+		}), func(r *http.Request, err error) {
+			t.Errorf("unexpected report: %v", err)
+		})
+		errors.OnPanic = func(err error) {
+			t.Errorf("unexpected OnPanic call: %v", err)
+		}
+
+		defer func() {
+			errors.OnPanic = nil
+			if r := recover(); r != http.ErrAbortHandler {
+				t.Errorf("expected http.ErrAbortHandler panic, got %v", r)
+			}
+		}()
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	})
+}
+
+// plainResponseWriter hides the optional interfaces of the wrapped http.ResponseWriter.
+type plainResponseWriter struct {
+	w http.ResponseWriter
+}
+
+func (w plainResponseWriter) Header() http.Header         { return w.w.Header() }
+func (w plainResponseWriter) Write(b []byte) (int, error) { return w.w.Write(b) }
+func (w plainResponseWriter) WriteHeader(statusCode int)  { w.w.WriteHeader(statusCode) }
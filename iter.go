@@ -0,0 +1,58 @@
+package errors
+
+import "iter"
+
+// Chain returns an iterator over every error in the tree of err, starting with err itself.
+// The tree is walked lazily, depth-first, in pre-order, as described in the package documentation.
+func Chain(err error) iter.Seq[error] {
+	return func(yield func(error) bool) {
+		walk(err, yield)
+	}
+}
+
+// All returns an iterator over every key-value pair in the tree of err, from the most recent to the oldest,
+// following the precedence described in the package documentation.
+// Overridden values and built-in key-value pairs, like Op, Severity and Code, are also yielded.
+func All(err error) iter.Seq2[any, any] {
+	return func(yield func(any, any) bool) {
+		for kv := range allKeyValuers(err) {
+			if !yield(kv.Key(), kv.Value()) {
+				return
+			}
+		}
+	}
+}
+
+// AllOf returns an iterator over the values associated with key in the tree of err that are of type T,
+// from the most recent to the oldest. Values of other types are skipped.
+func AllOf[T any](err error, key any) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for kv := range allKeyValuers(err) {
+			if kv.Key() != key {
+				continue
+			}
+			if t, ok := kv.Value().(T); ok && !yield(t) {
+				return
+			}
+		}
+	}
+}
+
+// allKeyValuers returns an iterator over every KeyValuer in the tree of err, in the same order as All.
+// Unlike All, it doesn't call Value, so lookups can compare keys without boxing values.
+func allKeyValuers(err error) iter.Seq[KeyValuer] {
+	return func(yield func(KeyValuer) bool) {
+		walk(err, func(e error) bool {
+			node, ok := e.(Error)
+			if !ok {
+				return true
+			}
+			for _, kv := range node.values() {
+				if !yield(kv) {
+					return false
+				}
+			}
+			return true
+		})
+	}
+}
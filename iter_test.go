@@ -0,0 +1,98 @@
+package errors
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestChain(t *testing.T) {
+	root1 := New("root 1")
+	root2 := New("root 2")
+	joined := Join(root1, root2)
+	wrapped := fmt.Errorf("wrapped: %w", joined)
+	err := With(wrapped, NoOp, KV("key", "value"))
+
+	want := []error{err, wrapped, joined, root1, root2}
+	got := slices.Collect(Chain(err))
+	if len(got) != len(want) {
+		t.Fatalf("expected %d errors, got %d", len(want), len(got))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("error %d: expected %v, got %v", i, want[i], got[i])
+		}
+	}
+
+	t.Run("stops early", func(t *testing.T) {
+		count := 0
+		for range Chain(err) {
+			count++
+			if count == 3 {
+				break
+			}
+		}
+		if count != 3 {
+			t.Errorf("expected 3 iterations, got %d", count)
+		}
+	})
+
+	t.Run("nil error", func(t *testing.T) {
+		for e := range Chain(nil) {
+			t.Errorf("unexpected error %v", e)
+		}
+	})
+}
+
+func TestAll(t *testing.T) {
+	err := With(
+		Join(
+			With(New("a"), NoOp, KV("key", "a")),
+			With(New("b"), NoOp, KV("key", 2)),
+		),
+		Op("op"),
+		SeverityInput,
+		KV("key", "top"),
+	)
+
+	type pair struct{ key, value any }
+	var got []pair
+	for k, v := range All(err) {
+		got = append(got, pair{k, v})
+	}
+
+	want := []pair{
+		{opKey{}, Op("op")},
+		{"key", "top"},
+		{severityKey{}, SeverityInput},
+		{"key", "a"},
+		{"key", 2},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	t.Run("stops early", func(t *testing.T) {
+		for k := range All(err) {
+			if k != (opKey{}) {
+				t.Errorf("expected the first key to be the Op, got %v", k)
+			}
+			break
+		}
+	})
+}
+
+func TestAllOf(t *testing.T) {
+	err := With(New("root"), NoOp, KV("key", "a"), KV("key", 1), KV("key", "b"), KV("other", "c"))
+
+	if got, want := slices.Collect(AllOf[string](err, "key")), []string{"b", "a"}; !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if got, want := slices.Collect(AllOf[int](err, "key")), []int{1}; !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if got := slices.Collect(AllOf[string](err, "missing")); got != nil {
+		t.Errorf("expected nil, got %v", got)
+	}
+}
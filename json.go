@@ -0,0 +1,149 @@
+package errors
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+)
+
+var (
+	_ json.Marshaler = Error{}
+
+	// JSONFormatter formats the error as a JSON object.
+	// The format is the same one produced by Error.MarshalJSON.
+	JSONFormatter Formatter = func(err error) string {
+		b, marshalErr := json.Marshal(newJSONError(err))
+		if marshalErr != nil {
+			return err.Error()
+		}
+		return string(b)
+	}
+)
+
+// MarshalJSON encodes the error chain as a JSON object. The format is as follows:
+//
+//	{
+//	  "message": "error message",
+//	  "root": "root error message",
+//	  "ops": ["operation2", "operation1"],
+//	  "severity": "input",
+//	  "code": "BAD_REQUEST",
+//	  "kv": {"key1": "value1", "key2": 2},
+//	  "kinds": ["NOT_FOUND"],
+//	  "joined": [{"message": "first joined error", ...}, ...]
+//	}
+//
+// Kinds lists the codes of the Kinds in the chain, so Decode can restore them.
+// Empty fields are omitted. Keys are stringified and values are encoded using encoding/json,
+// falling back to their string representation when they can't be encoded.
+// Errors found in values or joined in the root error are encoded with this same format.
+func (e Error) MarshalJSON() ([]byte, error) {
+	return json.Marshal(newJSONError(e))
+}
+
+type jsonError struct {
+	Message  string      `json:"message"`
+	Root     string      `json:"root"`
+	Ops      []Op        `json:"ops,omitempty"`
+	Severity Severity    `json:"severity,omitempty"`
+	Code     Code        `json:"code,omitempty"`
+	KV       jsonKVs     `json:"kv,omitempty"`
+	Kinds    []Code      `json:"kinds,omitempty"`
+	Joined   []jsonError `json:"joined,omitempty"`
+}
+
+// newJSONError encodes the linear chain of err, up to its root error. If the root error joins
+// other errors, they are encoded as its children, so their values are not mixed with the ones above them.
+func newJSONError(err error) jsonError {
+	jsonErr := jsonError{Message: err.Error()}
+	processed := make(map[any]struct{})
+
+	root := err
+	for e := err; e != nil; e = Unwrap(e) {
+		root = e
+		var kind *Kind
+		switch e := e.(type) {
+		case *Kind:
+			kind = e
+		case kindError:
+			kind = e.kind
+		}
+		if kind != nil && !slices.Contains(jsonErr.Kinds, kind.code) {
+			jsonErr.Kinds = append(jsonErr.Kinds, kind.code)
+		}
+
+		node, ok := e.(Error)
+		if !ok {
+			continue
+		}
+
+		for _, kv := range node.values() {
+			switch key, value := kv.Key(), kv.Value(); key {
+			case opKey{}:
+				if op, ok := value.(Op); ok {
+					jsonErr.Ops = append(jsonErr.Ops, op)
+				}
+			case severityKey{}:
+				if jsonErr.Severity == SeverityUnset {
+					jsonErr.Severity, _ = value.(Severity)
+				}
+			case codeKey{}:
+				if jsonErr.Code == CodeUnset {
+					jsonErr.Code, _ = value.(Code)
+				}
+			default:
+				if _, exists := processed[key]; exists || isBuiltInKeyValuer(key) {
+					continue
+				}
+				jsonErr.KV = append(jsonErr.KV, kv)
+				processed[key] = struct{}{}
+			}
+		}
+	}
+	jsonErr.Root = root.Error()
+
+	if joined, ok := root.(interface{ Unwrap() []error }); ok {
+		for _, child := range joined.Unwrap() {
+			if child != nil {
+				jsonErr.Joined = append(jsonErr.Joined, newJSONError(child))
+			}
+		}
+	}
+
+	return jsonErr
+}
+
+// jsonKVs encodes key-value pairs as a JSON object, preserving their order.
+type jsonKVs []KeyValuer
+
+func (kvs jsonKVs) MarshalJSON() ([]byte, error) {
+	buf := bytes.Buffer{}
+	buf.WriteByte('{')
+	for i, kv := range kvs {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(stringify(kv.Key()))
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(marshalJSONValue(kv.Value()))
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// marshalJSONValue encodes v using encoding/json. Errors that don't implement json.Marshaler
+// are encoded as error objects and values that can't be encoded are stringified.
+func marshalJSONValue(v any) []byte {
+	if err, ok := v.(error); ok {
+		if _, isMarshaler := v.(json.Marshaler); !isMarshaler {
+			v = newJSONError(err)
+		}
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		b, _ = json.Marshal(stringify(v))
+	}
+	return b
+}
@@ -0,0 +1,90 @@
+package errors_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/arquivei/errors"
+)
+
+type jsonMarshalerValue struct{}
+
+func (jsonMarshalerValue) MarshalJSON() ([]byte, error) {
+	return []byte(`"custom json"`), nil
+}
+
+type jsonKeyType int
+
+func TestErrorMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "simple error",
+			err:  errors.With(errors.New("some error"), errors.NoOp, errors.SeverityRuntime),
+			want: `{"message":"some error","root":"some error","severity":"runtime"}`,
+		},
+		{
+			name: "full error",
+			err: errors.With(
+				fmt.Errorf("wrapped: %w", errors.With(errors.New("some error"), errors.Op("op1"), errors.KV("key", "old value"))),
+				errors.Op("op2"),
+				errors.SeverityInput,
+				errors.Code("BAD_REQUEST"),
+				errors.KV("key", "value"),
+				errors.KV(jsonKeyType(1), 2),
+			),
+			want: `{"message":"wrapped: some error","root":"some error","ops":["op2","op1"],"severity":"input","code":"BAD_REQUEST","kv":{"1":2,"key":"value"}}`,
+		},
+		{
+			name: "nested values",
+			err: errors.With(
+				errors.New("some error"),
+				errors.NoOp,
+				errors.KV("map", map[string]any{"a": []int{1, 2}}),
+				errors.KV("marshaler", jsonMarshalerValue{}),
+				errors.KV("complex", complex(1, 2)),
+				errors.KV("error", errors.New("value error")),
+				errors.KV("nil", nil),
+			),
+			want: `{"message":"some error","root":"some error","kv":{"nil":null,"error":{"message":"value error","root":"value error"},"complex":"(1+2i)","marshaler":"custom json","map":{"a":[1,2]}}}`,
+		},
+		{
+			name: "joined errors",
+			err: errors.With(
+				errors.Join(
+					errors.With(errors.New("first"), errors.Op("op1"), errors.Code("FIRST")),
+					errors.New("second"),
+				),
+				errors.Op("op2"),
+			),
+			want: `{"message":"first\nsecond","root":"first\nsecond","ops":["op2"],"joined":[{"message":"first","root":"first","ops":["op1"],"code":"FIRST"},{"message":"second","root":"second"}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.err)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("expected '%s', got '%s'", tt.want, got)
+			}
+			if formatted := errors.JSONFormatter(tt.err); formatted != tt.want {
+				t.Errorf("expected '%s', got '%s'", tt.want, formatted)
+			}
+		})
+	}
+}
+
+func TestJSONFormatter(t *testing.T) {
+	err := errors.With(errors.New("some error"), errors.Op("op1"), errors.JSONFormatter)
+
+	expected := `{"message":"some error","root":"some error","ops":["op1"]}`
+	if got := errors.Format(err); got != expected {
+		t.Errorf("expected '%s', got '%s'", expected, got)
+	}
+}
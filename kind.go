@@ -0,0 +1,104 @@
+package errors
+
+var _ error = (*Kind)(nil)
+
+// Kind is a sentinel error that carries a Code and a Severity.
+// Errors created by a Kind match it with Is, even after being wrapped again
+// or after a serialization round trip with Decode.
+//
+// Example:
+//
+//	var ErrNotFound = errors.Define("NOT_FOUND", errors.SeverityInput, "not found")
+//
+//	func find(id string) error {
+//		return ErrNotFound.New(errors.KV("id", id))
+//	}
+//
+//	if errors.Is(err, ErrNotFound) {
+//		// handle not found
+//	}
+type Kind struct {
+	code     Code
+	severity Severity
+	msg      string
+}
+
+// Define declares a Kind with the given code, severity and message.
+// If the code is not registered in the DefaultRegistry, it's registered with the severity
+// and the message as description.
+// Kinds should be declared once, as package level variables.
+func Define(code Code, severity Severity, msg string) *Kind {
+	return DefaultRegistry.Define(code, severity, msg)
+}
+
+// Define is like the package level Define, registering the code in r instead of the DefaultRegistry.
+// Decoded errors are matched to Kinds only by their codes, so codes should not be shared by Kinds
+// of different registries.
+func (r *Registry) Define(code Code, severity Severity, msg string) *Kind {
+	k := &Kind{
+		code:     code,
+		severity: severity,
+		msg:      msg,
+	}
+
+	r.registerIfAbsent(CodeInfo{Code: code, Severity: severity, Description: msg})
+	registerKind(k)
+
+	return k
+}
+
+// Error returns the message of the Kind.
+func (k *Kind) Error() string {
+	return k.msg
+}
+
+// Code returns the code of the Kind.
+func (k *Kind) Code() Code {
+	return k.code
+}
+
+// Severity returns the severity of the Kind.
+func (k *Kind) Severity() Severity {
+	return k.severity
+}
+
+// New creates an error of this Kind with its code, severity and the given key-value pairs.
+// The message of the error is the message of the Kind.
+func (k *Kind) New(keyvalues ...KeyValuer) error {
+	return with(1, k, k.defaults(keyvalues)...)
+}
+
+// Wrap wraps err in an error of this Kind with its code, severity and the given key-value pairs.
+// The message of the error is the message of the Kind followed by the message of err.
+// The returned error matches both the Kind and err with Is.
+// If err is nil, Wrap returns nil.
+func (k *Kind) Wrap(err error, keyvalues ...KeyValuer) error {
+	if err == nil {
+		return nil
+	}
+	return with(1, kindError{kind: k, err: err}, k.defaults(keyvalues)...)
+}
+
+func (k *Kind) defaults(keyvalues []KeyValuer) []KeyValuer {
+	kvs := make([]KeyValuer, 0, len(keyvalues)+2)
+	kvs = append(kvs, k.code, k.severity)
+	return append(kvs, keyvalues...)
+}
+
+// kindError wraps an error in a Kind.
+type kindError struct {
+	kind *Kind
+	err  error
+}
+
+func (e kindError) Error() string {
+	return e.kind.msg + ": " + e.err.Error()
+}
+
+func (e kindError) Unwrap() error {
+	return e.err
+}
+
+func (e kindError) Is(target error) bool {
+	return target == e.kind
+}
@@ -0,0 +1,160 @@
+package errors_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/arquivei/errors"
+)
+
+var (
+	errKindNotFound = errors.Define("TEST_KIND_NOT_FOUND", errors.SeverityInput, "not found")
+	errKindTimeout  = errors.Define("TEST_KIND_TIMEOUT", errors.SeverityRuntime, "timeout")
+)
+
+func findForKindTest() error {
+	return errKindNotFound.New(errors.KV("id", 42))
+}
+
+func TestKindNew(t *testing.T) {
+	err := findForKindTest()
+
+	if !errors.Is(err, errKindNotFound) {
+		t.Error("expected error to match its kind")
+	}
+	if errors.Is(err, errKindTimeout) {
+		t.Error("expected error not to match another kind")
+	}
+
+	expected := "errors_test.findForKindTest: [input] (TEST_KIND_NOT_FOUND) not found {id=42}"
+	if got := errors.Format(err); got != expected {
+		t.Errorf("expected '%s', got '%s'", expected, got)
+	}
+
+	err = fmt.Errorf("wrapped: %w", errors.With(err, errors.KV("other", "value")))
+	if !errors.Is(err, errKindNotFound) {
+		t.Error("expected wrapped error to match its kind")
+	}
+	if code := errors.GetCode(err); code != errKindNotFound.Code() {
+		t.Errorf("expected code %s, got %s", errKindNotFound.Code(), code)
+	}
+	if severity := errors.GetSeverity(err); severity != errKindNotFound.Severity() {
+		t.Errorf("expected severity %s, got %s", errKindNotFound.Severity(), severity)
+	}
+}
+
+func TestKindWrap(t *testing.T) {
+	cause := errors.New("connection reset")
+	err := errKindTimeout.Wrap(cause, errors.Op("op1"))
+
+	if !errors.Is(err, errKindTimeout) {
+		t.Error("expected error to match its kind")
+	}
+	if !errors.Is(err, cause) {
+		t.Error("expected error to match its cause")
+	}
+	if err.Error() != "timeout: connection reset" {
+		t.Errorf("expected 'timeout: connection reset', got '%s'", err.Error())
+	}
+	if severity := errors.GetSeverity(err); severity != errors.SeverityRuntime {
+		t.Errorf("expected severity runtime, got %s", severity)
+	}
+
+	if err := errKindTimeout.Wrap(nil); err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+}
+
+func TestKindDecode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"new", errKindNotFound.New(errors.KV("id", 42))},
+		{"wrap", errKindNotFound.Wrap(errors.New("no rows"))},
+		{"wrapped by fmt", fmt.Errorf("wrapped: %w", errKindNotFound.New())},
+		{"wrapped with another code", errors.With(errKindNotFound.New(), errors.Code("TEST_KIND_OTHER"))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(errors.With(tt.err, errors.NoOp, errors.KV("key", "value")))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			decoded, err := errors.Decode(data)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !errors.Is(decoded, errKindNotFound) {
+				t.Error("expected decoded error to match its kind")
+			}
+			if errors.Is(decoded, errKindTimeout) {
+				t.Error("expected decoded error not to match another kind")
+			}
+			if decoded.Error() != tt.err.Error() {
+				t.Errorf("expected '%s', got '%s'", tt.err.Error(), decoded.Error())
+			}
+		})
+	}
+}
+
+func TestDefine(t *testing.T) {
+	info, ok := errors.LookupCode("TEST_KIND_NOT_FOUND")
+	if !ok {
+		t.Fatal("expected code to be registered")
+	}
+	if info.Severity != errors.SeverityInput || info.Description != "not found" {
+		t.Errorf("unexpected code info %+v", info)
+	}
+
+	registry := errors.NewRegistry()
+	registry.Register(errors.CodeInfo{Code: "TEST_KIND_PREREGISTERED", Description: "registered before"})
+	kind := registry.Define("TEST_KIND_PREREGISTERED", errors.SeverityFatal, "preregistered")
+	if info, _ := registry.Lookup(kind.Code()); info.Description != "registered before" {
+		t.Errorf("expected registered code info to be kept, got %+v", info)
+	}
+
+	kind = registry.Define("TEST_KIND_NEW", errors.SeverityFatal, "new")
+	if info, ok := registry.Lookup(kind.Code()); !ok || info.Description != "new" {
+		t.Errorf("expected code to be registered, got %+v", info)
+	}
+	if _, ok := errors.LookupCode(kind.Code()); ok {
+		t.Error("expected code not to be registered in the DefaultRegistry")
+	}
+}
+
+func TestKindDecodeNestedKinds(t *testing.T) {
+	data, err := json.Marshal(errKindTimeout.Wrap(errKindNotFound.New()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	decoded, err := errors.Decode(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !errors.Is(decoded, errKindTimeout) || !errors.Is(decoded, errKindNotFound) {
+		t.Error("expected decoded error to match both kinds")
+	}
+}
+
+func TestKindDecodeSameMessage(t *testing.T) {
+	err := errors.With(errors.New("not found"), errors.NoOp, errors.KV("id", 42))
+	if errors.Is(err, errKindNotFound) {
+		t.Fatal("expected error not to match the kind before encoding")
+	}
+
+	data, err := json.Marshal(err)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	decoded, err := errors.Decode(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if errors.Is(decoded, errKindNotFound) {
+		t.Error("expected decoded error not to match a kind with the same message")
+	}
+}
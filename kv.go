@@ -35,6 +35,17 @@ func KV(key any, value any) KeyValuer {
 	}
 }
 
+// fieldKey stringifies the key of a key-value pair written next to the msg, op, severity
+// and code fields, prefixing it with "kv." if it has the same name as one of them.
+func fieldKey(key any) string {
+	k := stringify(key)
+	switch k {
+	case "msg", "op", "severity", "code":
+		return "kv." + k
+	}
+	return k
+}
+
 // stringify tries a bit to stringify v, without using fmt, since we don't
 // want context depending on the unicode tables. This is only used by
 // *valueCtx.String().
@@ -0,0 +1,298 @@
+package errors
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+var (
+	// LogfmtFormatter formats the error as a logfmt line:
+	//
+	//	msg="error message" op="operation2: operation1" severity=input code=BAD_REQUEST key1=value1 key2="value 2"
+	//
+	// Empty fields are omitted. Keys are stringified and characters not allowed in logfmt keys
+	// are replaced by underscores. Keys named msg, op, severity or code are prefixed with "kv.".
+	// Values are quoted when needed, so they can't break the line or inject other fields.
+	// Quoted values escape only \", \\, \n, \r and \t, and other control or non-printable
+	// characters as \uXXXX, which standard logfmt parsers understand.
+	LogfmtFormatter Formatter = formatLogfmt
+
+	// ErrInvalidLogfmt is returned by ParseLogfmt when a line is not valid logfmt.
+	ErrInvalidLogfmt = New("invalid logfmt")
+)
+
+// LogfmtRecord is an error parsed from a logfmt line by ParseLogfmt.
+type LogfmtRecord struct {
+	Message  string
+	Ops      []Op
+	Severity Severity
+	Code     Code
+	// KV are the other fields of the line, in order.
+	KV []LogfmtField
+}
+
+// LogfmtField is a key-value pair of a logfmt line.
+type LogfmtField struct {
+	Key   string
+	Value string
+}
+
+func formatLogfmt(err error) string {
+	sb := strings.Builder{}
+	sb.Grow(64)
+
+	writeLogfmtField(&sb, "msg", err.Error())
+	if ops := GetOpStack(err); ops != "" {
+		writeLogfmtField(&sb, "op", ops)
+	}
+	if severity := GetSeverity(err); severity != SeverityUnset {
+		writeLogfmtField(&sb, "severity", severity.String())
+	}
+	if code := GetCode(err); code != CodeUnset {
+		writeLogfmtField(&sb, "code", code.String())
+	}
+	for _, kv := range ValueAllSlice(err) {
+		writeLogfmtField(&sb, fieldKey(kv.Key()), stringify(kv.Value()))
+	}
+
+	return sb.String()
+}
+
+func writeLogfmtField(sb *strings.Builder, key, value string) {
+	if sb.Len() > 0 {
+		sb.WriteByte(' ')
+	}
+	sb.WriteString(logfmtKey(key))
+	sb.WriteByte('=')
+	if logfmtNeedsQuote(value) {
+		writeLogfmtQuoted(sb, value)
+	} else {
+		sb.WriteString(value)
+	}
+}
+
+// writeLogfmtQuoted writes value quoted, escaping \" \\ \n \r \t, and other control or
+// non-printable characters as \uXXXX. Invalid UTF-8 is replaced by U+FFFD.
+func writeLogfmtQuoted(sb *strings.Builder, value string) {
+	sb.WriteByte('"')
+	for _, r := range value {
+		switch r {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\t':
+			sb.WriteString(`\t`)
+		default:
+			if r != utf8.RuneError && unicode.IsPrint(r) {
+				sb.WriteRune(r)
+				continue
+			}
+			if r1, r2 := utf16.EncodeRune(r); r1 != utf8.RuneError {
+				writeLogfmtUnicodeEscape(sb, r1)
+				r = r2
+			}
+			writeLogfmtUnicodeEscape(sb, r)
+		}
+	}
+	sb.WriteByte('"')
+}
+
+func writeLogfmtUnicodeEscape(sb *strings.Builder, r rune) {
+	const hex = "0123456789abcdef"
+	sb.WriteString(`\u`)
+	for shift := 12; shift >= 0; shift -= 4 {
+		sb.WriteByte(hex[r>>shift&0xf])
+	}
+}
+
+// logfmtKey replaces the characters that are not allowed in keys by underscores.
+func logfmtKey(key string) string {
+	if key == "" {
+		return "_"
+	}
+	return strings.Map(func(r rune) rune {
+		if !isLogfmtKeyRune(r) {
+			return '_'
+		}
+		return r
+	}, key)
+}
+
+func isLogfmtKeyRune(r rune) bool {
+	return r > ' ' && r != '=' && r != '"' && r != utf8.RuneError && unicode.IsPrint(r)
+}
+
+func logfmtNeedsQuote(value string) bool {
+	if value == "" {
+		return true
+	}
+	for _, r := range value {
+		if r == '\\' || !isLogfmtKeyRune(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseLogfmt parses a line written by LogfmtFormatter. The op field is split into
+// the operation stack, from the most recent to the oldest. Only the first msg, op,
+// severity and code fields are parsed as such, other fields are returned in KV.
+// Keys without values are parsed with empty values.
+func ParseLogfmt(line string) (LogfmtRecord, error) {
+	var record LogfmtRecord
+	seen := make(map[string]bool, 4)
+
+	for i := 0; i < len(line); {
+		if line[i] == ' ' || line[i] == '\t' {
+			i++
+			continue
+		}
+
+		start := i
+		for i < len(line) && line[i] != '=' && line[i] != ' ' && line[i] != '\t' {
+			if line[i] == '"' {
+				return LogfmtRecord{}, With(ErrInvalidLogfmt, KV("pos", i))
+			}
+			i++
+		}
+		key := line[start:i]
+		if key == "" {
+			return LogfmtRecord{}, With(ErrInvalidLogfmt, KV("pos", i))
+		}
+
+		var value string
+		if i < len(line) && line[i] == '=' {
+			i++
+			var err error
+			value, i, err = parseLogfmtValue(line, i)
+			if err != nil {
+				return LogfmtRecord{}, err
+			}
+		}
+
+		if !seen[key] && record.set(key, value) {
+			seen[key] = true
+			continue
+		}
+		record.KV = append(record.KV, LogfmtField{Key: key, Value: value})
+	}
+
+	return record, nil
+}
+
+// parseLogfmtValue parses the value starting at line[i] and returns it with the position after it.
+func parseLogfmtValue(line string, i int) (string, int, error) {
+	if i >= len(line) || line[i] != '"' {
+		start := i
+		for i < len(line) && line[i] != ' ' && line[i] != '\t' {
+			if line[i] == '"' || line[i] == '=' {
+				return "", 0, With(ErrInvalidLogfmt, KV("pos", i))
+			}
+			i++
+		}
+		return line[start:i], i, nil
+	}
+
+	start := i
+	sb := strings.Builder{}
+	for i++; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			r, n, ok := parseLogfmtEscape(line[i:])
+			if !ok {
+				return "", 0, With(ErrInvalidLogfmt, KV("pos", i))
+			}
+			sb.WriteRune(r)
+			i += n - 1
+		case '"':
+			if i+1 < len(line) && line[i+1] != ' ' && line[i+1] != '\t' {
+				return "", 0, With(ErrInvalidLogfmt, KV("pos", start))
+			}
+			return sb.String(), i + 1, nil
+		default:
+			sb.WriteByte(line[i])
+		}
+	}
+
+	return "", 0, With(ErrInvalidLogfmt, KV("pos", start))
+}
+
+// parseLogfmtEscape parses the escape sequence at the start of s, as written by writeLogfmtQuoted,
+// and returns the rune and the length of the sequence.
+func parseLogfmtEscape(s string) (rune, int, bool) {
+	if len(s) < 2 {
+		return 0, 0, false
+	}
+	switch s[1] {
+	case '"', '\\':
+		return rune(s[1]), 2, true
+	case 'n':
+		return '\n', 2, true
+	case 'r':
+		return '\r', 2, true
+	case 't':
+		return '\t', 2, true
+	case 'u':
+		r, ok := parseLogfmtHex(s[2:])
+		if !ok {
+			return 0, 0, false
+		}
+		if !utf16.IsSurrogate(r) {
+			return r, 6, true
+		}
+		if len(s) < 12 || s[6:8] != `\u` {
+			return 0, 0, false
+		}
+		r2, ok := parseLogfmtHex(s[8:])
+		if !ok {
+			return 0, 0, false
+		}
+		if r = utf16.DecodeRune(r, r2); r == utf8.RuneError {
+			return 0, 0, false
+		}
+		return r, 12, true
+	}
+	return 0, 0, false
+}
+
+// parseLogfmtHex parses the 4 hex digits at the start of s.
+func parseLogfmtHex(s string) (rune, bool) {
+	if len(s) < 4 {
+		return 0, false
+	}
+	v, err := strconv.ParseUint(s[:4], 16, 16)
+	if err != nil {
+		return 0, false
+	}
+	return rune(v), true
+}
+
+// set sets the field of the record named by key and reports whether key names a field.
+func (r *LogfmtRecord) set(key, value string) bool {
+	switch key {
+	case "msg":
+		r.Message = value
+	case "op":
+		if value == "" {
+			break
+		}
+		for op := range strings.SplitSeq(value, ": ") {
+			r.Ops = append(r.Ops, Op(op))
+		}
+	case "severity":
+		r.Severity = Severity(value)
+	case "code":
+		r.Code = Code(value)
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,165 @@
+package errors
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLogfmtFormatter(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "simple error",
+			err:  New("simple"),
+			want: "msg=simple",
+		},
+		{
+			name: "all fields",
+			err: With(New("root error"),
+				Op("op1"),
+				Op("op2"),
+				SeverityInput,
+				Code("BAD_REQUEST"),
+				KV("key", "value"),
+				KV("int", 2),
+			),
+			want: `msg="root error" op="op2: op1" severity=input code=BAD_REQUEST int=2 key=value`,
+		},
+		{
+			name: "escaping",
+			err: With(New("line 1\nline 2"),
+				NoOp,
+				KV("inject", "x msg=fake"),
+				KV("quote", `say "hi"`),
+				KV("backslash", `a\b`),
+				KV("empty", ""),
+				KV("key with spaces=\"", "v"),
+				KV("", "empty key"),
+			),
+			want: `msg="line 1\nline 2" _="empty key" key_with_spaces__=v empty="" backslash="a\\b" ` +
+				`quote="say \"hi\"" inject="x msg=fake"`,
+		},
+		{
+			name: "control characters",
+			err:  With(New("nul\x00 bell\a tab\t é \U0001F600 \U000E0001 \xff"), NoOp),
+			want: `msg="nul\u0000 bell\u0007 tab\t é 😀 \udb40\udc01 \ufffd"`,
+		},
+		{
+			name: "colliding keys",
+			err:  With(New("root error"), NoOp, Code("BAD_REQUEST"), KV("code", 42), KV("msg", "user")),
+			want: `msg="root error" code=BAD_REQUEST kv.msg=user kv.code=42`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LogfmtFormatter(tt.err); got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseLogfmt(t *testing.T) {
+	tests := []struct {
+		name    string
+		line    string
+		want    LogfmtRecord
+		wantErr bool
+	}{
+		{
+			name: "all fields",
+			line: `msg="root error" op="op2: op1" severity=input code=BAD_REQUEST int=2 key=value`,
+			want: LogfmtRecord{
+				Message:  "root error",
+				Ops:      []Op{"op2", "op1"},
+				Severity: SeverityInput,
+				Code:     "BAD_REQUEST",
+				KV:       []LogfmtField{{Key: "int", Value: "2"}, {Key: "key", Value: "value"}},
+			},
+		},
+		{
+			name: "escaped values",
+			line: `msg="line 1\nline 2"  quote="say \"hi\""	empty="" bare`,
+			want: LogfmtRecord{
+				Message: "line 1\nline 2",
+				KV: []LogfmtField{
+					{Key: "quote", Value: `say "hi"`},
+					{Key: "empty", Value: ""},
+					{Key: "bare", Value: ""},
+				},
+			},
+		},
+		{
+			name: "repeated fields",
+			line: `msg=first msg=second`,
+			want: LogfmtRecord{
+				Message: "first",
+				KV:      []LogfmtField{{Key: "msg", Value: "second"}},
+			},
+		},
+		{name: "unterminated quote", line: `msg="root error`, wantErr: true},
+		{name: "missing separator", line: `msg="a"b=c`, wantErr: true},
+		{name: "quote in key", line: `"msg"=a`, wantErr: true},
+		{name: "missing key", line: `=a`, wantErr: true},
+		{
+			name: "unicode escapes",
+			line: `msg="nul\u0000 \u00e9 \ud83d\ude00"`,
+			want: LogfmtRecord{Message: "nul\x00 é \U0001F600"},
+		},
+		{name: "invalid escape", line: `msg="\q"`, wantErr: true},
+		{name: "go escape", line: `msg="\x00"`, wantErr: true},
+		{name: "short unicode escape", line: `msg="\u00"`, wantErr: true},
+		{name: "unpaired surrogate", line: `msg="\ud83d"`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLogfmt(tt.line)
+			if tt.wantErr {
+				if !Is(err, ErrInvalidLogfmt) {
+					t.Errorf("expected ErrInvalidLogfmt, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %#v, got %#v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestLogfmtRoundTrip(t *testing.T) {
+	err := With(New("bad \"input\"\n"),
+		Op("main.handler"),
+		SeverityInput,
+		Code("BAD_REQUEST"),
+		KV("user", "x severity=fatal"),
+		KV("severity", "fatal"),
+		KV("control", "\x00\v\U0001F600"),
+	)
+
+	got, parseErr := ParseLogfmt(LogfmtFormatter(err))
+	if parseErr != nil {
+		t.Fatalf("unexpected error: %v", parseErr)
+	}
+
+	want := LogfmtRecord{
+		Message:  "bad \"input\"\n",
+		Ops:      []Op{"main.handler"},
+		Severity: SeverityInput,
+		Code:     "BAD_REQUEST",
+		KV: []LogfmtField{
+			{Key: "control", Value: "\x00\v\U0001F600"},
+			{Key: "kv.severity", Value: "fatal"},
+			{Key: "user", Value: "x severity=fatal"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %#v, got %#v", want, got)
+	}
+}
@@ -42,44 +42,129 @@ type opKey struct{}
 // GetOpStack retrieves the operation stack from an error.
 // It returns a string representation of the operations in the stack,
 // formatted as "op1: op2: ...", where each operation is separated by ": ".
+// The operations of joined errors are written as separate branches, like
+// "op1: [op2 | op3]". Branches without operations are skipped.
 // If no operations are found, it returns an empty string.
 func GetOpStack(err error) string {
-	ops := Values(err, opKey{})
-	if len(ops) == 0 {
-		return ""
-	}
-
 	sb := strings.Builder{}
 	sb.Grow(32)
+	writeOpStack(&sb, err, ": ")
+
+	return sb.String()
+}
 
-	sb.WriteString(stringify(ops[0]))
-	for _, op := range ops[1:] {
-		sb.WriteString(": ")
-		sb.WriteString(stringify(op))
+// writeOpStack writes the operations in the tree of err to sb, separated by sep,
+// and returns whether any was written.
+func writeOpStack(sb *strings.Builder, err error, sep string) bool {
+	written := false
+	separate := func() {
+		if written {
+			sb.WriteString(sep)
+		}
+		written = true
 	}
 
-	return sb.String()
+	for err != nil {
+		if e, ok := err.(Error); ok {
+			for _, kv := range e.values() {
+				if kv.Key() == (opKey{}) {
+					separate()
+					sb.WriteString(stringify(kv.Value()))
+				}
+			}
+		}
+
+		switch e := err.(type) {
+		case interface{ Unwrap() error }:
+			err = e.Unwrap()
+		case interface{ Unwrap() []error }:
+			var branches []string
+			for _, child := range e.Unwrap() {
+				branch := strings.Builder{}
+				if writeOpStack(&branch, child, sep) {
+					branches = append(branches, branch.String())
+				}
+			}
+			switch len(branches) {
+			case 0:
+			case 1:
+				separate()
+				sb.WriteString(branches[0])
+			default:
+				separate()
+				sb.WriteString("[")
+				sb.WriteString(strings.Join(branches, " | "))
+				sb.WriteString("]")
+			}
+			return written
+		default:
+			return written
+		}
+	}
+
+	return written
 }
 
 // opUnknownFunction is used when the function name cannot be determined.
 const opUnknownFunction Op = "<unknown function>"
 
+// maxOpCacheSize is the maximum number of Ops cached by getCallerOp.
+// Once it's reached, new Ops are resolved on every call.
+const maxOpCacheSize = 1 << 14
+
+type opCacheKey struct {
+	pc                    uintptr
+	alwaysIncludeLocation bool
+	verbose               bool
+}
+
 // getCallerOp retrieves the operation for the caller at the given program counter (pc).
+// The operations are cached by program counter, until the OpNamer is changed. Reads are lock-free.
 func getCallerOp(pc uintptr, alwaysIncludeLocation bool) Op {
+	naming := opNamingState.Load()
+	key := opCacheKey{
+		pc:                    pc,
+		alwaysIncludeLocation: alwaysIncludeLocation,
+		verbose:               VerboseOpOnAnonymousFunctions,
+	}
+	if op, ok := naming.ops.Load(key); ok {
+		return op.(Op)
+	}
+
+	op := resolveCallerOp(naming.namer, pc, alwaysIncludeLocation)
+	if naming.size.Load() < maxOpCacheSize {
+		if _, loaded := naming.ops.LoadOrStore(key, op); !loaded {
+			naming.size.Add(1)
+		}
+	}
+
+	return op
+}
+
+// resolveCallerOp resolves the operation for the caller at the given program counter (pc).
+func resolveCallerOp(namer OpNamer, pc uintptr, alwaysIncludeLocation bool) Op {
 	funcForPc := runtime.FuncForPC(pc)
 	if funcForPc == nil {
 		return opUnknownFunction
 	}
 
-	funcName := funcForPc.Name()
-	funcName = discardPackagePath(funcName)
-
-	if alwaysIncludeLocation || (VerboseOpOnAnonymousFunctions && isAnonymousFunction(funcName)) {
-		file, line := funcForPc.FileLine(pc)
-		return Op(funcNameWithLocation(funcName, file, line))
+	file, line := funcForPc.FileLine(pc)
+	frame := runtime.Frame{
+		PC:       pc,
+		Func:     funcForPc,
+		Function: funcForPc.Name(),
+		File:     file,
+		Line:     line,
 	}
+	return frameOp(namer, frame, alwaysIncludeLocation)
+}
+
+// frameOp names the operation of the frame with namer.
+func frameOp(namer OpNamer, frame runtime.Frame, alwaysIncludeLocation bool) Op {
+	withLocation := alwaysIncludeLocation ||
+		(VerboseOpOnAnonymousFunctions && isAnonymousFunction(discardPackagePath(frame.Function)))
 
-	return Op(funcName)
+	return namer.OpName(frame, withLocation)
 }
 
 // isAnonymousFunction checks if the function name indicates an anonymous function.
@@ -0,0 +1,163 @@
+package errors
+
+import (
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	_ OpNamer = OpNamerFunc(nil)
+	_ OpNamer = FuncNameOpNamer{}
+)
+
+// OpNamer names the Ops added automatically by With and DontPanic.
+// withLocation reports whether the Op should include the file and line of the frame,
+// which is always the case for panics and, if VerboseOpOnAnonymousFunctions is set,
+// for anonymous functions.
+// Implementations must be safe for concurrent use.
+type OpNamer interface {
+	OpName(frame runtime.Frame, withLocation bool) Op
+}
+
+// OpNamerFunc is a function that implements OpNamer.
+type OpNamerFunc func(frame runtime.Frame, withLocation bool) Op
+
+// OpName calls f.
+func (f OpNamerFunc) OpName(frame runtime.Frame, withLocation bool) Op {
+	return f(frame, withLocation)
+}
+
+// FuncNameOpNamer names Ops after the function of the frame, as reported by the runtime package.
+// Its zero value is the default OpNamer, which names Ops relative to their package,
+// like "pkg.(*Repo).Get" or "pkg.Func.func1 (file.go:42)".
+type FuncNameOpNamer struct {
+	// FullyQualified keeps the full package path: "github.com/org/repo/pkg.Func".
+	FullyQualified bool
+	// SimplifyReceivers removes the pointer noise from methods: "pkg.(*Repo).Get" becomes "pkg.Repo.Get".
+	SimplifyReceivers bool
+	// StripTypeParams removes the type parameters of generic functions and types: "pkg.greeter[...].sayHello"
+	// becomes "pkg.greeter.sayHello".
+	StripTypeParams bool
+	// CollapseClosures names closures after their enclosing function: "pkg.Func.func1.2" becomes "pkg.Func".
+	// The location of the closure is still added if VerboseOpOnAnonymousFunctions is set.
+	CollapseClosures bool
+}
+
+// OpName implements OpNamer.
+func (n FuncNameOpNamer) OpName(frame runtime.Frame, withLocation bool) Op {
+	if frame.Function == "" {
+		return opUnknownFunction
+	}
+
+	name := frame.Function
+	if !n.FullyQualified {
+		name = discardPackagePath(name)
+	}
+	if n.StripTypeParams {
+		name = stripTypeParams(name)
+	}
+	if n.SimplifyReceivers {
+		name = simplifyReceivers(name)
+	}
+	if n.CollapseClosures {
+		name = collapseClosures(name)
+	}
+
+	if withLocation {
+		return Op(funcNameWithLocation(name, frame.File, frame.Line))
+	}
+	return Op(name)
+}
+
+// SetOpNamer sets the OpNamer used to name automatic Ops. If namer is nil,
+// the default FuncNameOpNamer is used. The cached Ops are discarded.
+func SetOpNamer(namer OpNamer) {
+	if namer == nil {
+		namer = FuncNameOpNamer{}
+	}
+	opNamingState.Store(&opNaming{namer: namer})
+}
+
+// opNaming holds the current OpNamer and the Ops it named, cached by getCallerOp.
+// They are replaced together, so Ops named by a previous OpNamer are never returned.
+type opNaming struct {
+	namer OpNamer
+	ops   sync.Map // opCacheKey -> Op
+	size  atomic.Int64
+}
+
+var opNamingState = newOpNamingState()
+
+func newOpNamingState() *atomic.Pointer[opNaming] {
+	state := &atomic.Pointer[opNaming]{}
+	state.Store(&opNaming{namer: FuncNameOpNamer{}})
+	return state
+}
+
+// stripTypeParams removes everything between square brackets.
+func stripTypeParams(name string) string {
+	if !strings.Contains(name, "[") {
+		return name
+	}
+
+	sb := strings.Builder{}
+	sb.Grow(len(name))
+	depth := 0
+	for _, r := range name {
+		switch {
+		case r == '[':
+			depth++
+		case r == ']' && depth > 0:
+			depth--
+		case depth == 0:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// simplifyReceivers replaces pointer receivers like "(*Repo)" by "Repo".
+func simplifyReceivers(name string) string {
+	for {
+		start := strings.Index(name, "(*")
+		if start < 0 {
+			return name
+		}
+		end := strings.IndexByte(name[start:], ')')
+		if end < 0 {
+			return name
+		}
+		end += start
+		name = name[:start] + name[start+2:end] + name[end+1:]
+	}
+}
+
+// collapseClosures removes the closure suffixes, like ".func1" and ".2", from the function name.
+func collapseClosures(name string) string {
+	for {
+		idx := strings.LastIndex(name, ".")
+		if idx < 0 {
+			return name
+		}
+		if !isClosureSuffix(name[idx+1:]) {
+			// Package level closures are named like "pkg.glob..func1".
+			return strings.TrimSuffix(name, ".glob.")
+		}
+		name = name[:idx]
+	}
+}
+
+func isClosureSuffix(s string) bool {
+	s = strings.TrimPrefix(s, "func")
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
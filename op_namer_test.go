@@ -0,0 +1,117 @@
+package errors
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestFuncNameOpNamer(t *testing.T) {
+	const function = "github.com/org/repo/pkg.(*repo[...]).Get.func1.2"
+
+	tests := []struct {
+		name  string
+		namer FuncNameOpNamer
+		frame runtime.Frame
+		want  Op
+	}{
+		{
+			name:  "package relative",
+			frame: runtime.Frame{Function: function},
+			want:  "pkg.(*repo[...]).Get.func1.2",
+		},
+		{
+			name:  "fully qualified",
+			namer: FuncNameOpNamer{FullyQualified: true},
+			frame: runtime.Frame{Function: function},
+			want:  "github.com/org/repo/pkg.(*repo[...]).Get.func1.2",
+		},
+		{
+			name:  "simplify receivers",
+			namer: FuncNameOpNamer{SimplifyReceivers: true},
+			frame: runtime.Frame{Function: "pkg.(*Repo).Get"},
+			want:  "pkg.Repo.Get",
+		},
+		{
+			name:  "strip type params",
+			namer: FuncNameOpNamer{StripTypeParams: true},
+			frame: runtime.Frame{Function: "pkg.greeter[...].sayHello"},
+			want:  "pkg.greeter.sayHello",
+		},
+		{
+			name:  "collapse closures",
+			namer: FuncNameOpNamer{CollapseClosures: true},
+			frame: runtime.Frame{Function: "pkg.Func.func1.2"},
+			want:  "pkg.Func",
+		},
+		{
+			name:  "collapse package level closures",
+			namer: FuncNameOpNamer{CollapseClosures: true},
+			frame: runtime.Frame{Function: "pkg.glob..func1"},
+			want:  "pkg",
+		},
+		{
+			name:  "all options",
+			namer: FuncNameOpNamer{SimplifyReceivers: true, StripTypeParams: true, CollapseClosures: true},
+			frame: runtime.Frame{Function: function},
+			want:  "pkg.repo.Get",
+		},
+		{
+			name:  "with location",
+			namer: FuncNameOpNamer{CollapseClosures: true},
+			frame: runtime.Frame{Function: "pkg.Func.func1", File: "/src/pkg/file.go", Line: 42},
+			want:  "pkg.Func (file.go:42)",
+		},
+		{
+			name: "unknown function",
+			want: opUnknownFunction,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withLocation := tt.frame.File != ""
+			if got := tt.namer.OpName(tt.frame, withLocation); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+type opNamerRepo[T any] struct{}
+
+//go:noinline
+func (*opNamerRepo[T]) Get() error {
+	return func() error {
+		return With(New("not found"))
+	}()
+}
+
+func TestSetOpNamer(t *testing.T) {
+	defer SetOpNamer(nil)
+
+	get := func() string {
+		return GetOpStack((&opNamerRepo[int]{}).Get())
+	}
+
+	if got := get(); !strings.HasPrefix(got, "errors.(*opNamerRepo[...]).Get.func1 (op_namer_test.go:") {
+		t.Errorf("expected the default op, got %q", got)
+	}
+
+	SetOpNamer(FuncNameOpNamer{SimplifyReceivers: true, StripTypeParams: true, CollapseClosures: true})
+	if got := get(); !strings.HasPrefix(got, "errors.opNamerRepo.Get (op_namer_test.go:") {
+		t.Errorf("expected the collapsed op with its location, got %q", got)
+	}
+
+	defer func(verbose bool) { VerboseOpOnAnonymousFunctions = verbose }(VerboseOpOnAnonymousFunctions)
+	VerboseOpOnAnonymousFunctions = false
+	if got := get(); got != "errors.opNamerRepo.Get" {
+		t.Errorf("expected op %q, got %q", "errors.opNamerRepo.Get", got)
+	}
+
+	SetOpNamer(OpNamerFunc(func(frame runtime.Frame, withLocation bool) Op {
+		return "custom"
+	}))
+	if got := get(); got != "custom" {
+		t.Errorf("expected op %q, got %q", "custom", got)
+	}
+}
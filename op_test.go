@@ -28,3 +28,25 @@ func TestGetOpStack(t *testing.T) {
 		t.Errorf("expected %q, got %q", expected, actual)
 	}
 }
+
+func TestGetOpStackJoinedErrors(t *testing.T) {
+	err := errors.Join(
+		errors.With(errors.New("first"), errors.Op("op 1")),
+		errors.With(errors.New("second"), errors.Op("op 2")),
+	)
+	err = errors.With(err, errors.Op("op 3"))
+
+	expected := "op 3: [op 1 | op 2]"
+	if actual := errors.GetOpStack(err); expected != actual {
+		t.Errorf("expected %q, got %q", expected, actual)
+	}
+
+	err = errors.With(
+		errors.Join(errors.New("first"), errors.With(errors.New("second"), errors.Op("op 2"))),
+		errors.Op("op 1"),
+	)
+	expected = "op 1: op 2"
+	if actual := errors.GetOpStack(err); expected != actual {
+		t.Errorf("expected %q, got %q", expected, actual)
+	}
+}
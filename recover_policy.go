@@ -0,0 +1,93 @@
+package errors
+
+var (
+	// DefaultRecoverPolicy is the policy used by DontPanic, DontPanicT, DontPanicCtx, Go and Group.
+	// Its zero value recovers every panic with CodePanic and SeverityFatal.
+	DefaultRecoverPolicy RecoverPolicy
+
+	// OnPanic is called with every error created from a recovered panic, before it is returned.
+	// It can be used to report panics. It's disabled when nil.
+	OnPanic func(err error)
+)
+
+// RecoverPolicy configures how panics are recovered.
+type RecoverPolicy struct {
+	// Code is attached to the error created from a panic. If unset, CodePanic is used.
+	Code Code
+	// Severity is attached to the error created from a panic. If unset, SeverityFatal is used.
+	Severity Severity
+	// Repanic reports whether a panic value should not be recovered. These values are panicked again.
+	// If nil, all panics are recovered.
+	Repanic func(v any) bool
+	// OnPanic is called with the error created from a panic, before the global OnPanic hook.
+	OnPanic func(err error)
+}
+
+// DontPanicWithPolicy is like DontPanic, but the panic is recovered according to policy.
+func DontPanicWithPolicy[F func() | func() error](policy RecoverPolicy, fn F) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = policy.recovered(r)
+		}
+	}()
+	switch f := any(fn).(type) {
+	case func():
+		f()
+	case func() error:
+		err = f()
+	}
+	return
+}
+
+// recovered handles a recovered panic value. It must be called by the deferred function that recovered the panic.
+func (p RecoverPolicy) recovered(r any) error {
+	if p.Repanic != nil && p.Repanic(r) {
+		panic(r)
+	}
+
+	code := p.Code
+	if code == CodeUnset {
+		code = CodePanic
+	}
+	severity := p.Severity
+	if severity == SeverityUnset {
+		severity = SeverityFatal
+	}
+
+	err := newPanicError(r, code, severity)
+
+	if p.OnPanic != nil {
+		p.OnPanic(err)
+	}
+	if OnPanic != nil {
+		OnPanic(err)
+	}
+
+	return err
+}
+
+// RepanicOnType returns a Repanic function that matches panic values of type T.
+// T can be an interface, like runtime.Error.
+func RepanicOnType[T any]() func(v any) bool {
+	return func(v any) bool {
+		_, ok := v.(T)
+		return ok
+	}
+}
+
+// RepanicOnErrors returns a Repanic function that matches panic values that are errors
+// matching any of the targets with Is.
+func RepanicOnErrors(targets ...error) func(v any) bool {
+	return func(v any) bool {
+		err, ok := v.(error)
+		if !ok {
+			return false
+		}
+		for _, target := range targets {
+			if Is(err, target) {
+				return true
+			}
+		}
+		return false
+	}
+}
@@ -0,0 +1,118 @@
+package errors
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestDontPanicWithPolicy(t *testing.T) {
+	t.Run("code and severity", func(t *testing.T) {
+		policy := RecoverPolicy{Code: "MY_PANIC", Severity: SeverityRuntime}
+		err := DontPanicWithPolicy(policy, func() { panic("boom") })
+		if got := GetCode(err); got != "MY_PANIC" {
+			t.Errorf("expected code MY_PANIC, got %v", got)
+		}
+		if got := GetSeverity(err); got != SeverityRuntime {
+			t.Errorf("expected severity runtime, got %v", got)
+		}
+		if got := GetPanicValue(err); got != "boom" {
+			t.Errorf("expected panic value boom, got %v", got)
+		}
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		err := DontPanicWithPolicy(RecoverPolicy{}, func() error { panic("boom") })
+		if got := GetCode(err); got != CodePanic {
+			t.Errorf("expected code %v, got %v", CodePanic, got)
+		}
+		if got := GetSeverity(err); got != SeverityFatal {
+			t.Errorf("expected severity fatal, got %v", got)
+		}
+	})
+
+	t.Run("no panic", func(t *testing.T) {
+		want := New("my error")
+		if err := DontPanicWithPolicy(RecoverPolicy{}, func() error { return want }); err != want {
+			t.Errorf("expected %v, got %v", want, err)
+		}
+	})
+
+	t.Run("on panic hooks", func(t *testing.T) {
+		var calls []string
+		OnPanic = func(err error) {
+			calls = append(calls, "global: "+err.Error())
+		}
+		defer func() { OnPanic = nil }()
+
+		policy := RecoverPolicy{OnPanic: func(err error) {
+			calls = append(calls, "policy: "+err.Error())
+		}}
+		_ = DontPanicWithPolicy(policy, func() { panic("boom") })
+
+		want := []string{"policy: panic: boom", "global: panic: boom"}
+		if len(calls) != len(want) || calls[0] != want[0] || calls[1] != want[1] {
+			t.Errorf("expected calls %q, got %q", want, calls)
+		}
+	})
+}
+
+func TestDontPanicWithPolicyRepanic(t *testing.T) {
+	errAbort := New("abort")
+
+	tests := []struct {
+		name    string
+		repanic func(any) bool
+		value   any
+		want    bool
+	}{
+		{name: "runtime error", repanic: RepanicOnType[runtime.Error](), value: runtimeError(), want: true},
+		{name: "other type", repanic: RepanicOnType[runtime.Error](), value: "boom", want: false},
+		{name: "error", repanic: RepanicOnErrors(errAbort), value: With(errAbort), want: true},
+		{name: "other error", repanic: RepanicOnErrors(errAbort), value: New("boom"), want: false},
+		{name: "not an error", repanic: RepanicOnErrors(errAbort), value: "abort", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			OnPanic = func(error) { called = true }
+			defer func() { OnPanic = nil }()
+
+			repanicked := func() (repanicked bool) {
+				defer func() {
+					if r := recover(); r != nil {
+						repanicked = true
+						if r != tt.value {
+							t.Errorf("expected panic value %v, got %v", tt.value, r)
+						}
+					}
+				}()
+				_ = DontPanicWithPolicy(RecoverPolicy{Repanic: tt.repanic}, func() { panic(tt.value) })
+				return false
+			}()
+
+			if repanicked != tt.want {
+				t.Errorf("expected repanic %v, got %v", tt.want, repanicked)
+			}
+			if called == repanicked {
+				t.Errorf("expected OnPanic to be called only when recovered")
+			}
+		})
+	}
+}
+
+func TestDontPanicUsesDefaultRecoverPolicy(t *testing.T) {
+	DefaultRecoverPolicy = RecoverPolicy{Severity: SeverityRuntime}
+	defer func() { DefaultRecoverPolicy = RecoverPolicy{} }()
+
+	err := DontPanic(func() { panic("boom") })
+	if got := GetSeverity(err); got != SeverityRuntime {
+		t.Errorf("expected severity runtime, got %v", got)
+	}
+}
+
+func runtimeError() (r any) {
+	defer func() { r = recover() }()
+	var m map[string]int
+	m["a"] = 1
+	return nil
+}
@@ -0,0 +1,143 @@
+package errors
+
+import (
+	"iter"
+	"maps"
+	"slices"
+	"sync"
+)
+
+var (
+	// DefaultRegistry is the registry used by RegisterCode, LookupCode, GetSeverity and With.
+	// CodePanic is registered by default.
+	DefaultRegistry = newDefaultRegistry()
+
+	// OnUnregisteredCode is called by With when a Code that is not registered in the
+	// DefaultRegistry is attached to an error. It can be used to catch typos in codes,
+	// for example by logging a warning or failing a test. It's disabled when nil.
+	OnUnregisteredCode func(code Code)
+
+	// ErrCodeAlreadyRegistered is the panic value when a code is registered twice in a Registry.
+	ErrCodeAlreadyRegistered = New("code already registered")
+)
+
+// CodeInfo describes a registered error code.
+type CodeInfo struct {
+	// Code is the registered code.
+	Code Code
+	// Description documents what the code means.
+	Description string
+	// Severity is the default severity of errors with this code. It's returned by
+	// GetSeverity when the error has no severity.
+	Severity Severity
+	// HTTPStatus is the default HTTP status for errors with this code.
+	HTTPStatus int
+	// PublicMessage is a message that can be shown to users, without internal details.
+	PublicMessage string
+	// DocURL is a link to the documentation of the code.
+	DocURL string
+}
+
+// Registry is a catalog of error codes and their metadata.
+// It is safe for concurrent use.
+type Registry struct {
+	mu    sync.RWMutex
+	codes map[Code]CodeInfo
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		codes: make(map[Code]CodeInfo),
+	}
+}
+
+func newDefaultRegistry() *Registry {
+	r := NewRegistry()
+	r.codes[CodePanic] = CodeInfo{
+		Code:          CodePanic,
+		Description:   "A panic was recovered.",
+		Severity:      SeverityFatal,
+		HTTPStatus:    500,
+		PublicMessage: "Internal error.",
+	}
+	return r
+}
+
+// Register adds codes to the registry.
+// It panics with ErrCodeAlreadyRegistered if a code is already registered, as codes
+// should be declared only once.
+func (r *Registry) Register(infos ...CodeInfo) {
+	// The panic error is built after unlocking, as With looks up the code in the DefaultRegistry.
+	if code, ok := r.register(infos); !ok {
+		panic(With(ErrCodeAlreadyRegistered, code))
+	}
+}
+
+// register adds codes to the registry until a code is already registered.
+// It returns that code and false, or true if all codes were registered.
+func (r *Registry) register(infos []CodeInfo) (Code, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, info := range infos {
+		if _, exists := r.codes[info.Code]; exists {
+			return info.Code, false
+		}
+		r.codes[info.Code] = info
+	}
+	return CodeUnset, true
+}
+
+// registerIfAbsent adds the code to the registry if it's not registered yet.
+func (r *Registry) registerIfAbsent(info CodeInfo) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.codes[info.Code]; !exists {
+		r.codes[info.Code] = info
+	}
+}
+
+// Lookup returns the information about a code and whether it is registered.
+func (r *Registry) Lookup(code Code) (CodeInfo, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	info, ok := r.codes[code]
+	return info, ok
+}
+
+// All returns an iterator over all registered codes, sorted by code.
+// The iterator works on a snapshot of the registry.
+func (r *Registry) All() iter.Seq[CodeInfo] {
+	r.mu.RLock()
+	codes := slices.Sorted(maps.Keys(r.codes))
+	infos := make([]CodeInfo, 0, len(codes))
+	for _, code := range codes {
+		infos = append(infos, r.codes[code])
+	}
+	r.mu.RUnlock()
+
+	return slices.Values(infos)
+}
+
+// RegisterCode adds codes to the DefaultRegistry.
+// It panics with ErrCodeAlreadyRegistered if a code is already registered.
+func RegisterCode(infos ...CodeInfo) {
+	DefaultRegistry.Register(infos...)
+}
+
+// LookupCode returns the information about a code registered in the DefaultRegistry.
+func LookupCode(code Code) (CodeInfo, bool) {
+	return DefaultRegistry.Lookup(code)
+}
+
+func checkRegisteredCode(code Code) {
+	if OnUnregisteredCode == nil || code == CodeUnset {
+		return
+	}
+	if _, ok := LookupCode(code); !ok {
+		OnUnregisteredCode(code)
+	}
+}
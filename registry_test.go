@@ -0,0 +1,127 @@
+package errors_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/arquivei/errors"
+)
+
+func TestRegistry(t *testing.T) {
+	registry := errors.NewRegistry()
+	registry.Register(
+		errors.CodeInfo{Code: "NOT_FOUND", Description: "Resource not found.", Severity: errors.SeverityInput, HTTPStatus: 404},
+		errors.CodeInfo{Code: "BAD_REQUEST", Description: "Invalid request.", Severity: errors.SeverityInput, HTTPStatus: 400},
+	)
+
+	info, ok := registry.Lookup("NOT_FOUND")
+	if !ok {
+		t.Fatal("expected NOT_FOUND to be registered")
+	}
+	if info.Description != "Resource not found." || info.HTTPStatus != 404 {
+		t.Errorf("unexpected code info %+v", info)
+	}
+
+	if _, ok := registry.Lookup("BAD_REQEUST"); ok {
+		t.Error("expected BAD_REQEUST not to be registered")
+	}
+
+	var codes []errors.Code
+	for info := range registry.All() {
+		codes = append(codes, info.Code)
+	}
+	if !slices.Equal(codes, []errors.Code{"BAD_REQUEST", "NOT_FOUND"}) {
+		t.Errorf("expected [BAD_REQUEST NOT_FOUND], got %v", codes)
+	}
+
+	t.Run("duplicated code", func(t *testing.T) {
+		defer func() {
+			r := recover()
+			err, ok := r.(error)
+			if !ok || !errors.Is(err, errors.ErrCodeAlreadyRegistered) {
+				t.Errorf("expected ErrCodeAlreadyRegistered panic, got %v", r)
+			}
+			if errors.GetCode(err) != "NOT_FOUND" {
+				t.Errorf("expected code NOT_FOUND, got %s", errors.GetCode(err))
+			}
+		}()
+		registry.Register(errors.CodeInfo{Code: "NOT_FOUND"})
+	})
+}
+
+func TestDefaultRegistry(t *testing.T) {
+	info, ok := errors.LookupCode(errors.CodePanic)
+	if !ok {
+		t.Fatal("expected CodePanic to be registered")
+	}
+	if info.Severity != errors.SeverityFatal {
+		t.Errorf("expected severity fatal, got %s", info.Severity)
+	}
+
+	useRegistry(t, errors.NewRegistry())
+	errors.RegisterCode(errors.CodeInfo{Code: "TEST_DEFAULT_SEVERITY", Severity: errors.SeverityRuntime})
+
+	err := errors.With(errors.New("some error"), errors.Code("TEST_DEFAULT_SEVERITY"))
+	if severity := errors.GetSeverity(err); severity != errors.SeverityRuntime {
+		t.Errorf("expected default severity runtime, got %s", severity)
+	}
+
+	err = errors.With(err, errors.SeverityInput)
+	if severity := errors.GetSeverity(err); severity != errors.SeverityInput {
+		t.Errorf("expected severity input, got %s", severity)
+	}
+}
+
+func TestOnUnregisteredCode(t *testing.T) {
+	var unregistered []errors.Code
+	errors.OnUnregisteredCode = func(code errors.Code) {
+		unregistered = append(unregistered, code)
+	}
+	defer func() { errors.OnUnregisteredCode = nil }()
+
+	useRegistry(t, errors.NewRegistry())
+	errors.RegisterCode(errors.CodeInfo{Code: "TEST_REGISTERED"})
+
+	_ = errors.With(errors.New("some error"), errors.Code("TEST_REGISTERED"))
+	_ = errors.With(errors.New("some error"), errors.Code("TEST_UNREGISTERED"), errors.CodeUnset)
+
+	if !slices.Equal(unregistered, []errors.Code{"TEST_UNREGISTERED"}) {
+		t.Errorf("expected [TEST_UNREGISTERED], got %v", unregistered)
+	}
+}
+
+func TestRegisterDuplicatedCodeInDefaultRegistry(t *testing.T) {
+	registry := errors.NewRegistry()
+	useRegistry(t, registry)
+	errors.OnUnregisteredCode = func(errors.Code) {}
+	defer func() { errors.OnUnregisteredCode = nil }()
+
+	errors.RegisterCode(errors.CodeInfo{Code: "TEST_DUPLICATED"})
+
+	// The panic error is built by With, which looks up the code in the DefaultRegistry.
+	// It must not wait for the lock held by Register.
+	done := make(chan any)
+	go func() {
+		defer func() { done <- recover() }()
+		errors.RegisterCode(errors.CodeInfo{Code: "TEST_DUPLICATED"})
+	}()
+
+	select {
+	case r := <-done:
+		if err, ok := r.(error); !ok || !errors.Is(err, errors.ErrCodeAlreadyRegistered) {
+			t.Errorf("expected ErrCodeAlreadyRegistered panic, got %v", r)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("RegisterCode deadlocked")
+	}
+}
+
+// useRegistry replaces the DefaultRegistry with r until the end of the test,
+// so that codes registered by the test don't leak into other tests.
+func useRegistry(t *testing.T, r *errors.Registry) {
+	t.Helper()
+	previous := errors.DefaultRegistry
+	errors.DefaultRegistry = r
+	t.Cleanup(func() { errors.DefaultRegistry = previous })
+}
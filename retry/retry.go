@@ -0,0 +1,156 @@
+// Package retry retries operations that fail with errors that are worth retrying,
+// like the ones with errors.SeverityRuntime.
+package retry
+
+import (
+	"context"
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/arquivei/errors"
+)
+
+// DefaultPolicy is the policy whose values are used for the unset fields of a Policy.
+var DefaultPolicy = Policy{
+	MaxAttempts:    3,
+	InitialBackoff: 100 * time.Millisecond,
+	MaxBackoff:     10 * time.Second,
+	Multiplier:     2,
+	Jitter:         0.2,
+}
+
+// Clock waits for durations. It allows tests to control time.
+type Clock interface {
+	After(d time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// Policy configures how Do retries an operation.
+// Zero values are replaced by the values from DefaultPolicy, except for Jitter.
+type Policy struct {
+	// MaxAttempts is the maximum number of times the operation is executed.
+	MaxAttempts int
+	// InitialBackoff is the time waited after the first failed attempt.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the time waited between attempts.
+	MaxBackoff time.Duration
+	// Multiplier multiplies the backoff after each failed attempt.
+	Multiplier float64
+	// Jitter randomizes the backoff by up to this fraction in both directions.
+	// For example, 0.2 means the backoff is randomly changed by up to 20%.
+	Jitter float64
+	// ShouldRetry reports whether an error should be retried. If nil, IsRetryable is used.
+	ShouldRetry func(err error) bool
+	// Clock is used to wait between attempts. If nil, the system clock is used.
+	Clock Clock
+}
+
+func (p Policy) withDefaults() Policy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = DefaultPolicy.MaxAttempts
+	}
+	if p.InitialBackoff <= 0 {
+		p.InitialBackoff = DefaultPolicy.InitialBackoff
+	}
+	if p.MaxBackoff <= 0 {
+		p.MaxBackoff = DefaultPolicy.MaxBackoff
+	}
+	if p.Multiplier <= 0 {
+		p.Multiplier = DefaultPolicy.Multiplier
+	}
+	if p.ShouldRetry == nil {
+		p.ShouldRetry = IsRetryable
+	}
+	if p.Clock == nil {
+		p.Clock = systemClock{}
+	}
+	return p
+}
+
+// backoff returns how long to wait after the given failed attempt.
+// A RetryAfter attached to err takes precedence over the computed backoff.
+func (p Policy) backoff(attempt int, err error) time.Duration {
+	if retryAfter, ok := errors.Value(err, retryAfterKey{}).(RetryAfter); ok {
+		return time.Duration(retryAfter)
+	}
+
+	backoff := float64(p.InitialBackoff) * math.Pow(p.Multiplier, float64(attempt-1))
+	if p.Jitter > 0 {
+		backoff *= 1 + p.Jitter*(2*rand.Float64()-1)
+	}
+	return time.Duration(min(backoff, float64(p.MaxBackoff)))
+}
+
+// IsRetryable reports whether err has errors.SeverityRuntime.
+func IsRetryable(err error) bool {
+	return errors.GetSeverity(err) == errors.SeverityRuntime
+}
+
+// Do executes fn until it succeeds, the policy says the error should not be retried,
+// the maximum number of attempts is reached or ctx is done.
+//
+// The returned error is the error of the last attempt annotated with the number of
+// attempts (see GetAttempts) and the errors of every attempt (see GetAttemptErrors).
+// If ctx is done while waiting for the next attempt, the returned error also wraps ctx.Err().
+func Do(ctx context.Context, fn func(ctx context.Context) error, policy Policy) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	policy = policy.withDefaults()
+
+	var attemptErrs []error
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+		attemptErrs = append(attemptErrs, err)
+
+		if attempt >= policy.MaxAttempts || !policy.ShouldRetry(err) {
+			return annotate(err, attemptErrs)
+		}
+
+		select {
+		case <-ctx.Done():
+			return annotate(errors.Errorf("%w: %w", err, ctx.Err()), attemptErrs)
+		case <-policy.Clock.After(policy.backoff(attempt, err)):
+		}
+	}
+}
+
+func annotate(err error, attemptErrs []error) error {
+	return errors.With(err,
+		errors.NoOp,
+		errors.KV(attemptsKey{}, len(attemptErrs)),
+		errors.KV(attemptErrorsKey{}, attemptErrs),
+	)
+}
+
+type attemptsKey struct{}
+
+func (attemptsKey) String() string {
+	return "attempts"
+}
+
+type attemptErrorsKey struct{}
+
+func (attemptErrorsKey) String() string {
+	return "attempt_errors"
+}
+
+// GetAttempts returns how many attempts were made by Do, or 0 if err was not returned by Do.
+func GetAttempts(err error) int {
+	return errors.ValueT[int](err, attemptsKey{})
+}
+
+// GetAttemptErrors returns the errors of each attempt made by Do, from the first to the last one.
+func GetAttemptErrors(err error) []error {
+	return errors.ValueT[[]error](err, attemptErrorsKey{})
+}
@@ -0,0 +1,32 @@
+package retry
+
+import (
+	"time"
+
+	"github.com/arquivei/errors"
+)
+
+var _ errors.KeyValuer = RetryAfter(0)
+
+// RetryAfter is how long Do should wait before retrying an operation that failed
+// with this error, instead of the backoff computed from the policy.
+// For example, it can be set from the Retry-After header of an HTTP response.
+type RetryAfter time.Duration
+
+type retryAfterKey struct{}
+
+func (RetryAfter) Key() any {
+	return retryAfterKey{}
+}
+
+func (r RetryAfter) Value() any {
+	return r
+}
+
+func (r RetryAfter) String() string {
+	return time.Duration(r).String()
+}
+
+func (retryAfterKey) String() string {
+	return "retry_after"
+}
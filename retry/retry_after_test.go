@@ -0,0 +1,18 @@
+package retry_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/arquivei/errors"
+	"github.com/arquivei/errors/retry"
+)
+
+func TestRetryAfter(t *testing.T) {
+	err := errors.With(errors.New("too many requests"), errors.Op("op1"), retry.RetryAfter(2*time.Second))
+
+	expected := "op1: too many requests {retry_after=2s}"
+	if got := errors.Format(err); got != expected {
+		t.Errorf("expected '%s', got '%s'", expected, got)
+	}
+}
@@ -0,0 +1,222 @@
+package retry_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/arquivei/errors"
+	"github.com/arquivei/errors/retry"
+)
+
+type fakeClock struct {
+	waits []time.Duration
+	block bool
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.waits = append(c.waits, d)
+	ch := make(chan time.Time, 1)
+	if !c.block {
+		ch <- time.Time{}
+	}
+	return ch
+}
+
+func failing(errs ...error) (func(ctx context.Context) error, *int) {
+	calls := 0
+	return func(ctx context.Context) error {
+		calls++
+		if calls > len(errs) {
+			return nil
+		}
+		return errs[calls-1]
+	}, &calls
+}
+
+func TestDo(t *testing.T) {
+	errRuntime := errors.With(errors.New("timeout"), errors.SeverityRuntime)
+	errInput := errors.With(errors.New("bad input"), errors.SeverityInput)
+
+	t.Run("succeeds after retries", func(t *testing.T) {
+		clock := &fakeClock{}
+		fn, calls := failing(errRuntime, errRuntime)
+
+		err := retry.Do(context.Background(), fn, retry.Policy{
+			MaxAttempts:    5,
+			InitialBackoff: time.Second,
+			Clock:          clock,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if *calls != 3 {
+			t.Errorf("expected 3 calls, got %d", *calls)
+		}
+		if expected := []time.Duration{time.Second, 2 * time.Second}; !reflect.DeepEqual(clock.waits, expected) {
+			t.Errorf("expected waits %v, got %v", expected, clock.waits)
+		}
+	})
+
+	t.Run("does not retry input errors", func(t *testing.T) {
+		clock := &fakeClock{}
+		fn, calls := failing(errInput)
+
+		err := retry.Do(context.Background(), fn, retry.Policy{Clock: clock})
+		if !errors.Is(err, errInput) {
+			t.Fatalf("expected input error, got %v", err)
+		}
+		if *calls != 1 {
+			t.Errorf("expected 1 call, got %d", *calls)
+		}
+		if attempts := retry.GetAttempts(err); attempts != 1 {
+			t.Errorf("expected 1 attempt, got %d", attempts)
+		}
+	})
+
+	t.Run("keeps the op stack", func(t *testing.T) {
+		clock := &fakeClock{}
+		fn, _ := failing(errors.With(errors.New("bad input"), errors.SeverityInput, errors.Op("myop")))
+
+		err := retry.Do(context.Background(), fn, retry.Policy{Clock: clock})
+		if ops := errors.GetOpStack(err); ops != "myop" {
+			t.Errorf("expected op stack myop, got %q", ops)
+		}
+	})
+
+	t.Run("custom predicate", func(t *testing.T) {
+		clock := &fakeClock{}
+		fn, calls := failing(errInput)
+
+		err := retry.Do(context.Background(), fn, retry.Policy{
+			Clock:       clock,
+			ShouldRetry: func(err error) bool { return true },
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if *calls != 2 {
+			t.Errorf("expected 2 calls, got %d", *calls)
+		}
+	})
+
+	t.Run("max attempts", func(t *testing.T) {
+		clock := &fakeClock{}
+		err1 := errors.With(errors.New("timeout 1"), errors.SeverityRuntime)
+		err2 := errors.With(errors.New("timeout 2"), errors.SeverityRuntime)
+		err3 := errors.With(errors.New("timeout 3"), errors.SeverityRuntime)
+		fn, calls := failing(err1, err2, err3, err3)
+
+		err := retry.Do(context.Background(), fn, retry.Policy{
+			MaxAttempts:    3,
+			InitialBackoff: time.Second,
+			MaxBackoff:     3 * time.Second,
+			Multiplier:     4,
+			Clock:          clock,
+		})
+		if !errors.Is(err, err3) {
+			t.Fatalf("expected last error, got %v", err)
+		}
+		if *calls != 3 {
+			t.Errorf("expected 3 calls, got %d", *calls)
+		}
+		if attempts := retry.GetAttempts(err); attempts != 3 {
+			t.Errorf("expected 3 attempts, got %d", attempts)
+		}
+		if attemptErrs := retry.GetAttemptErrors(err); !reflect.DeepEqual(attemptErrs, []error{err1, err2, err3}) {
+			t.Errorf("expected attempt errors %v, got %v", []error{err1, err2, err3}, attemptErrs)
+		}
+		if expected := []time.Duration{time.Second, 3 * time.Second}; !reflect.DeepEqual(clock.waits, expected) {
+			t.Errorf("expected waits %v, got %v", expected, clock.waits)
+		}
+	})
+
+	t.Run("retry after", func(t *testing.T) {
+		clock := &fakeClock{}
+		fn, _ := failing(errors.With(errRuntime, retry.RetryAfter(time.Minute)))
+
+		err := retry.Do(context.Background(), fn, retry.Policy{Clock: clock})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if expected := []time.Duration{time.Minute}; !reflect.DeepEqual(clock.waits, expected) {
+			t.Errorf("expected waits %v, got %v", expected, clock.waits)
+		}
+	})
+
+	t.Run("jitter", func(t *testing.T) {
+		clock := &fakeClock{}
+		fn, _ := failing(errRuntime, errRuntime, errRuntime)
+
+		err := retry.Do(context.Background(), fn, retry.Policy{
+			MaxAttempts:    4,
+			InitialBackoff: time.Second,
+			Jitter:         0.5,
+			Clock:          clock,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for i, wait := range clock.waits {
+			base := time.Second << i
+			if wait < base/2 || wait > base*3/2 {
+				t.Errorf("expected wait %d to be within 50%% of %v, got %v", i, base, wait)
+			}
+		}
+	})
+
+	t.Run("context canceled while waiting", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		clock := &fakeClock{block: true}
+		fn := func(ctx context.Context) error {
+			cancel()
+			return errRuntime
+		}
+
+		err := retry.Do(ctx, fn, retry.Policy{Clock: clock})
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+		if !errors.Is(err, errRuntime) {
+			t.Errorf("expected runtime error, got %v", err)
+		}
+		if attempts := retry.GetAttempts(err); attempts != 1 {
+			t.Errorf("expected 1 attempt, got %d", attempts)
+		}
+	})
+
+	t.Run("context canceled before the first attempt", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		fn, calls := failing()
+
+		err := retry.Do(ctx, fn, retry.Policy{})
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+		if *calls != 0 {
+			t.Errorf("expected no calls, got %d", *calls)
+		}
+	})
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"no severity", errors.New("some error"), false},
+		{"runtime", errors.With(errors.New("some error"), errors.SeverityRuntime), true},
+		{"input", errors.With(errors.New("some error"), errors.SeverityInput), false},
+		{"fatal", errors.With(errors.New("some error"), errors.SeverityFatal), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retry.IsRetryable(tt.err); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
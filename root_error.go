@@ -1,12 +1,40 @@
 package errors
 
-import "errors"
-
 // GetRootError returns the root error of the error chain.
+// If the chain has joined errors, it returns the first root error found (see GetRootErrors).
 func GetRootError(err error) error {
-	for unwrapped := errors.Unwrap(err); unwrapped != nil; unwrapped = errors.Unwrap(unwrapped) {
-		err = unwrapped
-	}
+	walk(err, func(e error) bool {
+		err = e
+		return isWrapper(e)
+	})
 
 	return err
 }
+
+// GetRootErrors returns all root errors of the error tree, in the order they are found.
+// A root error is an error that doesn't wrap any other error.
+// For an error without joined errors, it returns a slice with the same error returned by GetRootError.
+func GetRootErrors(err error) []error {
+	var roots []error
+
+	walk(err, func(e error) bool {
+		if !isWrapper(e) {
+			roots = append(roots, e)
+		}
+		return true
+	})
+
+	return roots
+}
+
+// isWrapper reports whether err wraps other errors.
+func isWrapper(err error) bool {
+	switch e := err.(type) {
+	case interface{ Unwrap() error }:
+		return e.Unwrap() != nil
+	case interface{ Unwrap() []error }:
+		return len(e.Unwrap()) > 0
+	default:
+		return false
+	}
+}
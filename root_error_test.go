@@ -18,3 +18,31 @@ func TestGetRootError(t *testing.T) {
 		t.Errorf("expected %v, got %v", rootErr, got)
 	}
 }
+
+func TestGetRootErrors(t *testing.T) {
+	first := errors.New("first")
+	second := errors.New("second")
+	third := errors.New("third")
+
+	err := errors.Join(
+		errors.With(first, errors.Op("op1")),
+		fmt.Errorf("wrapped: %w, %w", second, errors.With(third, errors.Op("op3"))),
+	)
+	err = errors.With(err, errors.Op("op"))
+
+	if got := errors.GetRootError(err); got != first {
+		t.Errorf("expected %v, got %v", first, got)
+	}
+
+	got := errors.GetRootErrors(err)
+	if len(got) != 3 || got[0] != first || got[1] != second || got[2] != third {
+		t.Errorf("expected [first second third], got %v", got)
+	}
+
+	if got := errors.GetRootErrors(first); len(got) != 1 || got[0] != first {
+		t.Errorf("expected [first], got %v", got)
+	}
+	if got := errors.GetRootErrors(nil); got != nil {
+		t.Errorf("expected nil, got %v", got)
+	}
+}
@@ -32,12 +32,17 @@ func (s Severity) Value() any {
 	return s
 }
 
-// Value returns the severity of the error. If there is not severity, Unset is returned.
+// GetSeverity returns the severity of the error. If there is not severity, the default severity
+// of the error code registered in the DefaultRegistry is returned. Otherwise, Unset is returned.
 func GetSeverity(err error) Severity {
 	val := Value(err, severityKey{})
 	if severity, ok := val.(Severity); ok {
 		return severity
 	}
 
+	if info, ok := LookupCode(GetCode(err)); ok {
+		return info.Severity
+	}
+
 	return SeverityUnset
 }
@@ -0,0 +1,155 @@
+package errors
+
+import (
+	"context"
+	"log/slog"
+)
+
+var (
+	_ slog.LogValuer = Error{}
+	_ slog.Handler   = (*SlogHandler)(nil)
+
+	// DefaultSeverityLevels is the severity to log level mapping used by SlogHandler
+	// when no mapping is given in SlogHandlerOptions.
+	DefaultSeverityLevels = map[Severity]slog.Level{
+		SeverityInput:   slog.LevelWarn,
+		SeverityRuntime: slog.LevelError,
+		SeverityFatal:   slog.LevelError,
+	}
+)
+
+// LogValue implements slog.LogValuer. The error is logged as a group with the
+// error message (msg), operation stack (op), severity, code and all key-value pairs.
+// Empty fields are omitted and keys are stringified. Keys named msg, op, severity
+// or code are prefixed with "kv.".
+func (e Error) LogValue() slog.Value {
+	return slog.GroupValue(logAttrs(e)...)
+}
+
+func logAttrs(err error) []slog.Attr {
+	kvs := ValueAllSlice(err)
+
+	attrs := make([]slog.Attr, 0, 4+len(kvs))
+	attrs = append(attrs, slog.String("msg", err.Error()))
+	if ops := GetOpStack(err); ops != "" {
+		attrs = append(attrs, slog.String("op", ops))
+	}
+	if severity := GetSeverity(err); severity != SeverityUnset {
+		attrs = append(attrs, slog.String("severity", severity.String()))
+	}
+	if code := GetCode(err); code != CodeUnset {
+		attrs = append(attrs, slog.String("code", code.String()))
+	}
+	for _, kv := range kvs {
+		attrs = append(attrs, slog.Any(fieldKey(kv.Key()), kv.Value()))
+	}
+
+	return attrs
+}
+
+// SlogHandlerOptions are options for a SlogHandler.
+type SlogHandlerOptions struct {
+	// Levels maps the severity of a logged error to the level of the record.
+	// The first error attribute with a mapped severity raises the level of the record,
+	// if the mapped level is higher. The level is never lowered.
+	// Errors added through WithAttrs are expanded but don't change the level.
+	// If nil, DefaultSeverityLevels is used. Use an empty map to keep the records' levels.
+	Levels map[Severity]slog.Level
+}
+
+// SlogHandler is a slog.Handler that expands any error attribute into a group
+// with the same format used by Error.LogValue, so errors wrapped by other packages
+// are also logged with their operation stack, severity, code and key-value pairs.
+// The expanded records are handled by the next handler.
+type SlogHandler struct {
+	next   slog.Handler
+	levels map[Severity]slog.Level
+}
+
+// NewSlogHandler creates a SlogHandler that wraps next.
+// If opts is nil, the default options are used.
+func NewSlogHandler(next slog.Handler, opts *SlogHandlerOptions) *SlogHandler {
+	levels := DefaultSeverityLevels
+	if opts != nil && opts.Levels != nil {
+		levels = opts.Levels
+	}
+
+	return &SlogHandler{
+		next:   next,
+		levels: levels,
+	}
+}
+
+// Enabled reports whether the next handler handles records at the given level.
+// Note that the level is checked before the record is mapped by its error severity.
+func (h *SlogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle expands the error attributes of the record, raises its level according to
+// the error severity and passes it to the next handler.
+func (h *SlogHandler) Handle(ctx context.Context, r slog.Record) error {
+	level := r.Level
+	levelMapped := false
+	attrs := make([]slog.Attr, 0, r.NumAttrs())
+
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, expandErrorAttr(a, func(err error) {
+			if levelMapped {
+				return
+			}
+			if mapped, ok := h.levels[GetSeverity(err)]; ok {
+				level = max(level, mapped)
+				levelMapped = true
+			}
+		}))
+		return true
+	})
+
+	expanded := slog.NewRecord(r.Time, level, r.Message, r.PC)
+	expanded.AddAttrs(attrs...)
+
+	return h.next.Handle(ctx, expanded)
+}
+
+// WithAttrs returns a new SlogHandler whose next handler has the expanded attributes.
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	expanded := make([]slog.Attr, 0, len(attrs))
+	for _, a := range attrs {
+		expanded = append(expanded, expandErrorAttr(a, func(error) {}))
+	}
+
+	return &SlogHandler{
+		next:   h.next.WithAttrs(expanded),
+		levels: h.levels,
+	}
+}
+
+// WithGroup returns a new SlogHandler whose next handler has the given group.
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	return &SlogHandler{
+		next:   h.next.WithGroup(name),
+		levels: h.levels,
+	}
+}
+
+// expandErrorAttr replaces errors in the attribute, including inside groups,
+// and calls onError for each error found.
+func expandErrorAttr(a slog.Attr, onError func(error)) slog.Attr {
+	switch a.Value.Kind() {
+	case slog.KindGroup:
+		group := a.Value.Group()
+		expanded := make([]slog.Attr, 0, len(group))
+		for _, ga := range group {
+			expanded = append(expanded, expandErrorAttr(ga, onError))
+		}
+		return slog.Attr{Key: a.Key, Value: slog.GroupValue(expanded...)}
+	case slog.KindAny, slog.KindLogValuer:
+		if err, ok := a.Value.Any().(error); ok && err != nil {
+			onError(err)
+			return slog.Attr{Key: a.Key, Value: slog.GroupValue(logAttrs(err)...)}
+		}
+	}
+
+	return a
+}
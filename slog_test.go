@@ -0,0 +1,119 @@
+package errors_test
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/arquivei/errors"
+)
+
+func newTestSlogLogger(buf *bytes.Buffer, opts *errors.SlogHandlerOptions) *slog.Logger {
+	jsonHandler := slog.NewJSONHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey && len(groups) == 0 {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+	return slog.New(errors.NewSlogHandler(jsonHandler, opts))
+}
+
+func TestErrorLogValue(t *testing.T) {
+	err := errors.With(
+		errors.New("some error"),
+		errors.Op("op1"),
+		errors.SeverityInput,
+		errors.Code("BAD_REQUEST"),
+		errors.KV("key", "value"),
+		errors.KV("int", 1),
+	)
+
+	buf := bytes.Buffer{}
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey && len(groups) == 0 {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	logger.Info("failed", "error", err)
+
+	expected := "level=INFO msg=failed error.msg=\"some error\" error.op=op1 error.severity=input error.code=BAD_REQUEST error.int=1 error.key=value\n"
+	if buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+}
+
+func TestSlogHandler(t *testing.T) {
+	wrapped := fmt.Errorf("wrapped: %w", errors.With(
+		errors.New("some error"),
+		errors.Op("op1"),
+		errors.SeverityInput,
+		errors.KV("key", "value"),
+	))
+
+	tests := []struct {
+		name string
+		opts *errors.SlogHandlerOptions
+		log  func(logger *slog.Logger)
+		want string
+	}{
+		{
+			name: "expands wrapped error and maps level",
+			log: func(logger *slog.Logger) {
+				logger.Info("failed", "error", wrapped)
+			},
+			want: `{"level":"WARN","msg":"failed","error":{"msg":"wrapped: some error","op":"op1","severity":"input","key":"value"}}`,
+		},
+		{
+			name: "expands errors inside groups and keeps higher levels",
+			log: func(logger *slog.Logger) {
+				logger.Error("failed", slog.Group("request", slog.Int("id", 1), slog.Any("err", wrapped)))
+			},
+			want: `{"level":"ERROR","msg":"failed","request":{"id":1,"err":{"msg":"wrapped: some error","op":"op1","severity":"input","key":"value"}}}`,
+		},
+		{
+			name: "prefixes colliding keys",
+			log: func(logger *slog.Logger) {
+				logger.Info("failed", "error", errors.With(errors.New("some error"), errors.NoOp, errors.KV("msg", "user"), errors.KV("code", 42)))
+			},
+			want: `{"level":"INFO","msg":"failed","error":{"msg":"some error","kv.code":42,"kv.msg":"user"}}`,
+		},
+		{
+			name: "expands errors in WithAttrs",
+			log: func(logger *slog.Logger) {
+				logger.With("error", wrapped).WithGroup("g").Info("failed", "a", "b")
+			},
+			want: `{"level":"INFO","msg":"failed","error":{"msg":"wrapped: some error","op":"op1","severity":"input","key":"value"},"g":{"a":"b"}}`,
+		},
+		{
+			name: "custom levels",
+			opts: &errors.SlogHandlerOptions{Levels: map[errors.Severity]slog.Level{}},
+			log: func(logger *slog.Logger) {
+				logger.Info("failed", "error", wrapped)
+			},
+			want: `{"level":"INFO","msg":"failed","error":{"msg":"wrapped: some error","op":"op1","severity":"input","key":"value"}}`,
+		},
+		{
+			name: "record without errors",
+			log: func(logger *slog.Logger) {
+				logger.Info("hello", "key", "value")
+			},
+			want: `{"level":"INFO","msg":"hello","key":"value"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := bytes.Buffer{}
+			tt.log(newTestSlogLogger(&buf, tt.opts))
+			if got := strings.TrimSpace(buf.String()); got != tt.want {
+				t.Errorf("expected '%s', got '%s'", tt.want, got)
+			}
+		})
+	}
+}
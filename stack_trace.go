@@ -0,0 +1,97 @@
+package errors
+
+import (
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+var (
+	_ KeyValuer = StackTrace(nil)
+
+	// CaptureStackTrace determines whether the call stack should be captured by the With function.
+	// If set to true, the first call to With on an error chain captures the call stack leading to it.
+	// Subsequent calls to With on the same chain do not capture it again.
+	// It's disabled by default because capturing the call stack has a cost on every new error chain.
+	CaptureStackTrace = false
+
+	// MaxStackTraceDepth is the maximum number of frames captured in a StackTrace.
+	MaxStackTraceDepth = 32
+)
+
+type stackTraceKey struct{}
+
+// StackTrace holds the program counters of a call stack, from the innermost to the outermost call.
+// The program counters are only resolved into functions, files and lines when the frames are requested,
+// so capturing a StackTrace is cheap compared to formatting it.
+type StackTrace []uintptr
+
+func (StackTrace) Key() any {
+	return stackTraceKey{}
+}
+
+func (st StackTrace) Value() any {
+	return st
+}
+
+// Frames resolves the program counters into stack frames.
+func (st StackTrace) Frames() []StackFrame {
+	if len(st) == 0 {
+		return nil
+	}
+
+	frames := make([]StackFrame, 0, len(st))
+	callersFrames := runtime.CallersFrames(st)
+	for {
+		frame, more := callersFrames.Next()
+		frames = append(frames, StackFrame{
+			Function: frame.Function,
+			File:     frame.File,
+			Line:     frame.Line,
+		})
+		if !more {
+			break
+		}
+	}
+
+	return frames
+}
+
+// String returns the stack trace with one frame per line.
+func (st StackTrace) String() string {
+	sb := strings.Builder{}
+	for i, frame := range st.Frames() {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(frame.String())
+	}
+	return sb.String()
+}
+
+// StackFrame is a resolved frame of a StackTrace.
+type StackFrame struct {
+	Function string
+	File     string
+	Line     int
+}
+
+// String returns the frame formatted as "function (file:line)".
+func (f StackFrame) String() string {
+	return f.Function + " (" + filepath.Base(f.File) + ":" + strconv.Itoa(f.Line) + ")"
+}
+
+// GetStackTrace retrieves the stack trace from an error and resolves it into frames.
+// It returns nil if no stack trace was captured.
+func GetStackTrace(err error) []StackFrame {
+	return ValueT[StackTrace](err, stackTraceKey{}).Frames()
+}
+
+// captureStackTrace captures the current call stack. The argument skip is the number of stack frames
+// to skip before recording, with 0 identifying the caller of captureStackTrace.
+func captureStackTrace(skip int) StackTrace {
+	pcs := make([]uintptr, MaxStackTraceDepth)
+	n := runtime.Callers(skip+2, pcs)
+	return StackTrace(pcs[:n])
+}
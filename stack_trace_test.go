@@ -0,0 +1,66 @@
+package errors_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/arquivei/errors"
+)
+
+func newStackTraceError() error {
+	return errors.With(errors.New("some error"), errors.KV("key", "value"))
+}
+
+func wrapStackTraceError(err error) error {
+	return errors.With(err, errors.SeverityRuntime)
+}
+
+func TestGetStackTrace(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		err := newStackTraceError()
+		if frames := errors.GetStackTrace(err); frames != nil {
+			t.Errorf("expected no stack trace, got %v", frames)
+		}
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		errors.CaptureStackTrace = true
+		defer func() { errors.CaptureStackTrace = false }()
+
+		err := wrapStackTraceError(newStackTraceError())
+
+		frames := errors.GetStackTrace(err)
+		if len(frames) < 2 {
+			t.Fatalf("expected at least 2 frames, got %v", frames)
+		}
+		if !strings.HasSuffix(frames[0].Function, "errors_test.newStackTraceError") {
+			t.Errorf("expected first frame to be newStackTraceError, got %s", frames[0].Function)
+		}
+		if !strings.HasSuffix(frames[1].Function, "errors_test.TestGetStackTrace.func2") {
+			t.Errorf("expected second frame to be the test function, got %s", frames[1].Function)
+		}
+		if !strings.HasSuffix(frames[0].File, "stack_trace_test.go") || frames[0].Line != 11 {
+			t.Errorf("expected stack_trace_test.go:11, got %s", frames[0])
+		}
+
+		if stackTraces := errors.ValuesT[errors.StackTrace](err, errors.StackTrace(nil).Key()); len(stackTraces) != 1 {
+			t.Errorf("expected stack trace to be captured once, got %d", len(stackTraces))
+		}
+
+		expected := "errors_test.wrapStackTraceError: errors_test.newStackTraceError: [runtime] some error {key=value}"
+		if got := errors.Format(err); got != expected {
+			t.Errorf("expected '%s', got '%s'", expected, got)
+		}
+	})
+}
+
+func TestStackTraceString(t *testing.T) {
+	if got := errors.StackTrace(nil).String(); got != "" {
+		t.Errorf("expected empty string, got %q", got)
+	}
+
+	frame := errors.StackFrame{Function: "pkg.Func", File: "/path/to/file.go", Line: 42}
+	if got := frame.String(); got != "pkg.Func (file.go:42)" {
+		t.Errorf("expected 'pkg.Func (file.go:42)', got %q", got)
+	}
+}
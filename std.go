@@ -1,5 +1,6 @@
 // Description: This file contains the standard error functions so that it's not necessary
 // import the errors package if this package is already imported.
+
 package errors
 
 import (
@@ -0,0 +1,91 @@
+package errors
+
+import (
+	"strconv"
+	"strings"
+)
+
+// TreeFormatter formats the error as a tree, with one line per level, indented by its depth:
+//
+//	operation2: [fatal] {key2=value2}
+//	  wrapped: root error
+//	    operation1: [input] (BAD_REQUEST) {key1=value1}
+//	      root error
+//
+// Each Frame is a level. Errors that wrap other errors, like the ones created by fmt.Errorf,
+// are written with their messages and joined errors are written as branches below a
+// "joined N errors" line. Values are written in the level they were added, so values
+// overridden by more recent ones are also shown.
+var TreeFormatter Formatter = func(err error) string {
+	sb := strings.Builder{}
+	sb.Grow(64)
+
+	writeTree(&sb, err, 0)
+
+	return sb.String()
+}
+
+func writeTree(sb *strings.Builder, err error, depth int) {
+	for err != nil {
+		if _, ok := err.(Error); ok {
+			var frames []Frame
+			frames, err = linearFrames(err)
+			for _, frame := range frames {
+				writeTreeLine(sb, depth, treeLine(frame))
+				depth++
+			}
+			continue
+		}
+
+		switch e := err.(type) {
+		case interface{ Unwrap() []error }:
+			children := make([]error, 0, len(e.Unwrap()))
+			for _, child := range e.Unwrap() {
+				if child != nil {
+					children = append(children, child)
+				}
+			}
+			writeTreeLine(sb, depth, "joined "+strconv.Itoa(len(children))+" errors")
+			for _, child := range children {
+				writeTree(sb, child, depth+1)
+			}
+			return
+		case interface{ Unwrap() error }:
+			writeTreeLine(sb, depth, err.Error())
+			depth++
+			err = e.Unwrap()
+		default:
+			writeTreeLine(sb, depth, err.Error())
+			return
+		}
+	}
+}
+
+// writeTreeLine writes a line indented by depth. Lines inside s are also indented.
+func writeTreeLine(sb *strings.Builder, depth int, s string) {
+	indent := strings.Repeat("  ", depth)
+	if sb.Len() > 0 {
+		sb.WriteByte('\n')
+	}
+	sb.WriteString(indent)
+	sb.WriteString(strings.ReplaceAll(s, "\n", "\n"+indent))
+}
+
+// treeLine returns the line of a frame of the tree.
+func treeLine(f Frame) string {
+	sections := make([]string, 0, 4)
+	if f.Op != "" {
+		sections = append(sections, f.Op.String()+":")
+	}
+	if f.Severity != SeverityUnset {
+		sections = append(sections, "["+f.Severity.String()+"]")
+	}
+	if f.Code != CodeUnset {
+		sections = append(sections, "("+f.Code.String()+")")
+	}
+	if kvs := defaultFormatterConfig.formatKV(f.KVs); kvs != "" {
+		sections = append(sections, kvs)
+	}
+
+	return strings.Join(sections, " ")
+}
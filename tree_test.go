@@ -0,0 +1,87 @@
+package errors
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestTreeFormatter(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "root error",
+			err:  New("root error"),
+			want: "root error",
+		},
+		{
+			name: "wrapped levels",
+			err: func() error {
+				err := With(New("root error"), Op("op1"), SeverityInput, Code("BAD_REQUEST"), KV("key1", "value1"))
+				err = fmt.Errorf("wrapped: %w", err)
+				return With(err, Op("op2"), SeverityFatal, KV("key2", "value2"), KV("key1", "override"))
+			}(),
+			want: "op2: [fatal] {key1=override, key2=value2}\n" +
+				"  wrapped: root error\n" +
+				"    op1: [input] (BAD_REQUEST) {key1=value1}\n" +
+				"      root error",
+		},
+		{
+			name: "values without op",
+			err:  With(New("root error"), NoOp, KV("key", "value")),
+			want: "{key=value}\n  root error",
+		},
+		{
+			name: "joined errors",
+			err: With(
+				Join(
+					With(New("item 1 failed"), Op("processItem"), KV("item", 1)),
+					nil,
+					With(New("item 2 failed"), Op("processItem"), KV("item", 2)),
+				),
+				Op("process"),
+				Code("BATCH_FAILED"),
+			),
+			want: "process: (BATCH_FAILED)\n" +
+				"  joined 2 errors\n" +
+				"    processItem: {item=1}\n" +
+				"      item 1 failed\n" +
+				"    processItem: {item=2}\n" +
+				"      item 2 failed",
+		},
+		{
+			name: "multi-line messages are indented",
+			err: With(
+				fmt.Errorf("wrapped: %w", Join(New("a"), New("b"))),
+				Op("op1"),
+			),
+			want: "op1:\n" +
+				"  wrapped: a\n" +
+				"  b\n" +
+				"    joined 2 errors\n" +
+				"      a\n" +
+				"      b",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TreeFormatter(tt.err); got != tt.want {
+				t.Errorf("expected:\n%s\ngot:\n%s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestWithAddsOpsLast(t *testing.T) {
+	err := With(New("root error"), Op("op1"), KV("key", "value"), Op("op2"), SeverityInput)
+
+	e, ok := err.(Error)
+	if !ok || e.values()[0] != Op("op2") {
+		t.Fatalf("expected the last Op to be the most recent value, got %#v", err)
+	}
+	if got := GetOpStack(err); got != "op2: op1" {
+		t.Errorf("expected op stack %q, got %q", "op2: op1", got)
+	}
+}
@@ -1,17 +1,16 @@
 package errors
 
 import (
-	"errors"
 	"reflect"
+	"slices"
 )
 
 // Value returns the last (more recent) value associated with the given key from the error chain.
+// Errors joined by errors.Join are also searched, following the precedence described in the package documentation.
 func Value(err error, key any) any {
-	for ; err != nil; err = errors.Unwrap(err) {
-		if e, ok := err.(Error); ok {
-			if e.keyval != nil && e.keyval.Key() == key {
-				return e.keyval.Value()
-			}
+	for kv := range allKeyValuers(err) {
+		if kv.Key() == key {
+			return kv.Value()
 		}
 	}
 
@@ -25,17 +24,13 @@ func ValueT[T any](err error, key any) T {
 }
 
 // Values returns a slice of values associated with the given key from the error chain.
-// It traverses the error chain and collects all values that match the specified key.
+// It traverses the error chain, including joined errors, and collects all values that match the specified key.
 // If there are multiple values for the same key, all of them are included in the slice.
 func Values(err error, key any) []any {
 	var values []any
-
-	var e Error
-	for ; err != nil; err = errors.Unwrap(err) {
-		if errors.As(err, &e) {
-			if e.keyval != nil && e.keyval.Key() == key {
-				values = append(values, e.keyval.Value())
-			}
+	for kv := range allKeyValuers(err) {
+		if kv.Key() == key {
+			values = append(values, kv.Value())
 		}
 	}
 
@@ -46,42 +41,23 @@ func Values(err error, key any) []any {
 // It traverses the error chain and collects all values that match the specified key.
 // If there are multiple values for the same key, all of them are included in the slice.
 func ValuesT[T any](err error, key any) []T {
-	values := Values(err, key)
-	if len(values) == 0 {
-		return nil
-	}
-	tValues := make([]T, 0, len(values))
-	for _, v := range values {
-		if v == nil {
-			continue
-		}
-		if t, ok := v.(T); ok {
-			tValues = append(tValues, t)
-		}
-
-	}
-	return tValues
+	return slices.Collect(AllOf[T](err, key))
 }
 
 // ValueAllSlice returns a slice of all values from the error chain.
-// It skips built-in key-value pairs like code, severity, operation, and formatter.
+// It skips built-in key-value pairs like code, severity, operation, formatter, stack trace and panic value.
 // If there are multiple values for the same key, only the first occurrence (last added) is included.
 func ValueAllSlice(err error) []KeyValuer {
 	var values []KeyValuer
 	processed := make(map[any]struct{})
 
-	for ; err != nil; err = errors.Unwrap(err) {
-		if e, ok := err.(Error); ok {
-			if e.keyval == nil {
-				continue
-			}
-			if isBuiltInKeyValuer(e.keyval.Key()) {
-				continue
-			}
-			if _, exists := processed[e.keyval.Key()]; !exists {
-				values = append(values, e.keyval)
-				processed[e.keyval.Key()] = struct{}{}
-			}
+	for kv := range allKeyValuers(err) {
+		if isBuiltInKeyValuer(kv.Key()) {
+			continue
+		}
+		if _, exists := processed[kv.Key()]; !exists {
+			values = append(values, kv)
+			processed[kv.Key()] = struct{}{}
 		}
 	}
 
@@ -92,14 +68,9 @@ func ValueAllSlice(err error) []KeyValuer {
 // It collects all values associated with the same key, allowing multiple values for the same key.
 func ValuesMapOf(err error, keyType any) map[any][]any {
 	m := make(map[any][]any)
-	for ; err != nil; err = errors.Unwrap(err) {
-		if e, ok := err.(Error); ok {
-			if e.keyval == nil {
-				continue
-			}
-			if reflect.TypeOf(e.keyval.Key()) == reflect.TypeOf(keyType) {
-				m[e.keyval.Key()] = append(m[e.keyval.Key()], e.keyval.Value())
-			}
+	for k, v := range All(err) {
+		if reflect.TypeOf(k) == reflect.TypeOf(keyType) {
+			m[k] = append(m[k], v)
 		}
 	}
 
@@ -107,21 +78,16 @@ func ValuesMapOf(err error, keyType any) map[any][]any {
 }
 
 // ValueMap returns a map of key-value pairs from the error chain.
-// It skips built-in key-value pairs like code, severity, operation, and formatter.
+// It skips built-in key-value pairs like code, severity, operation, formatter, stack trace and panic value.
 // If there are multiple values for the same key, only the first occurrence (last added) is included.
 func ValueMap(err error) map[any]any {
 	m := make(map[any]any)
-	for ; err != nil; err = errors.Unwrap(err) {
-		if e, ok := err.(Error); ok {
-			if e.keyval == nil {
-				continue
-			}
-			if isBuiltInKeyValuer(e.keyval.Key()) {
-				continue
-			}
-			if _, ok := m[e.keyval.Key()]; !ok {
-				m[e.keyval.Key()] = e.keyval.Value()
-			}
+	for k, v := range All(err) {
+		if isBuiltInKeyValuer(k) {
+			continue
+		}
+		if _, ok := m[k]; !ok {
+			m[k] = v
 		}
 	}
 
@@ -132,16 +98,12 @@ func ValueMap(err error) map[any]any {
 // If there are multiple values for the same key, only the first occurrence (last added) is included.
 func ValueMapOf(err error, keyType any) map[any]any {
 	m := make(map[any]any)
-	for ; err != nil; err = errors.Unwrap(err) {
-		if e, ok := err.(Error); ok {
-			if e.keyval == nil {
-				continue
-			}
-			if reflect.TypeOf(e.keyval.Key()) == reflect.TypeOf(keyType) {
-				if _, ok := m[e.keyval.Key()]; !ok {
-					m[e.keyval.Key()] = e.keyval.Value()
-				}
-			}
+	for k, v := range All(err) {
+		if reflect.TypeOf(k) != reflect.TypeOf(keyType) {
+			continue
+		}
+		if _, ok := m[k]; !ok {
+			m[k] = v
 		}
 	}
 
@@ -150,7 +112,7 @@ func ValueMapOf(err error, keyType any) map[any]any {
 
 func isBuiltInKeyValuer(key any) bool {
 	switch key {
-	case codeKey{}, severityKey{}, opKey{}, formatterKey{}:
+	case codeKey{}, severityKey{}, opKey{}, formatterKey{}, stackTraceKey{}, panicValueKey{}:
 		return true
 	default:
 		return false
@@ -1,6 +1,7 @@
 package errors
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
 )
@@ -252,3 +253,84 @@ func TestValueAllSlice(t *testing.T) {
 		}
 	}
 }
+
+func TestValueJoinedErrors(t *testing.T) {
+	first := With(New("first"), NoOp, KV("key", "first"), Code("FIRST"))
+	second := With(New("second"), NoOp, KV("key", "second"), KV("key2", "second"), Code("SECOND"))
+	third := With(New("third"), NoOp, KV("key3", "third"))
+
+	joined := Join(first, Errorf("wrapped: %w, %w", second, third))
+	err := With(joined, NoOp, KV("top", "top"))
+
+	if v := Value(err, "key"); v != "first" {
+		t.Errorf("expected 'first', got %v", v)
+	}
+	if v := Value(err, "key3"); v != "third" {
+		t.Errorf("expected 'third', got %v", v)
+	}
+	if v := Values(err, "key"); !reflect.DeepEqual(v, []any{"first", "second"}) {
+		t.Errorf("expected [first second], got %v", v)
+	}
+	if code := GetCode(err); code != "FIRST" {
+		t.Errorf("expected code FIRST, got %s", code)
+	}
+
+	expectedMap := map[any]any{"top": "top", "key": "first", "key2": "second", "key3": "third"}
+	if m := ValueMap(err); !reflect.DeepEqual(m, expectedMap) {
+		t.Errorf("expected %v, got %v", expectedMap, m)
+	}
+
+	expectedSlice := []KeyValuer{KV("top", "top"), KV("key", "first"), KV("key2", "second"), KV("key3", "third")}
+	if s := ValueAllSlice(err); !reflect.DeepEqual(s, expectedSlice) {
+		t.Errorf("expected %v, got %v", expectedSlice, s)
+	}
+
+	expectedMapOf := map[any][]any{"top": {"top"}, "key": {"first", "second"}, "key2": {"second"}, "key3": {"third"}}
+	if m := ValuesMapOf(err, ""); !reflect.DeepEqual(m, expectedMapOf) {
+		t.Errorf("expected %v, got %v", expectedMapOf, m)
+	}
+	if m := ValueMapOf(err, ""); !reflect.DeepEqual(m, expectedMap) {
+		t.Errorf("expected %v, got %v", expectedMap, m)
+	}
+}
+
+// deepChain wraps an error depth times with an op, code, severity and three key-value pairs.
+func deepChain(depth int) error {
+	err := New("root error")
+	for i := range depth {
+		err = With(err,
+			Op("op"),
+			Code("MY_CODE"),
+			SeverityInput,
+			KV("key", i),
+			KV("other", i),
+			KV(i, i),
+		)
+	}
+	return err
+}
+
+func BenchmarkValue(b *testing.B) {
+	for _, depth := range []int{1, 10, 100} {
+		err := deepChain(depth)
+
+		b.Run(fmt.Sprintf("most recent/depth=%d", depth), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				_ = Value(err, "key")
+			}
+		})
+		b.Run(fmt.Sprintf("oldest/depth=%d", depth), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				_ = Value(err, 0)
+			}
+		})
+		b.Run(fmt.Sprintf("ValueAllSlice/depth=%d", depth), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				_ = ValueAllSlice(err)
+			}
+		})
+	}
+}
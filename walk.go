@@ -0,0 +1,69 @@
+package errors
+
+// walk calls fn for each error in the tree of err, stopping if fn returns false.
+// It returns false if the walk was stopped.
+//
+// The tree is walked depth-first, in pre-order, as described in the package documentation.
+func walk(err error, fn func(error) bool) bool {
+	for err != nil {
+		if !fn(err) {
+			return false
+		}
+
+		switch e := err.(type) {
+		case interface{ Unwrap() error }:
+			err = e.Unwrap()
+		case interface{ Unwrap() []error }:
+			for _, child := range e.Unwrap() {
+				if !walk(child, fn) {
+					return false
+				}
+			}
+			return true
+		default:
+			return true
+		}
+	}
+
+	return true
+}
+
+// leafValues returns, for each root error of the tree of err, the most recent value associated
+// with key in the path from the top of the tree to that root error. Root errors without the key
+// are skipped.
+func leafValues(err error, key any) []any {
+	var values []any
+
+	var visit func(err error, value any)
+	visit = func(err error, value any) {
+		for err != nil {
+			if e, ok := err.(Error); ok && value == nil {
+				for _, kv := range e.values() {
+					if kv.Key() == key {
+						value = kv.Value()
+						break
+					}
+				}
+			}
+
+			switch e := err.(type) {
+			case interface{ Unwrap() error }:
+				err = e.Unwrap()
+			case interface{ Unwrap() []error }:
+				for _, child := range e.Unwrap() {
+					visit(child, value)
+				}
+				return
+			default:
+				err = nil
+			}
+		}
+
+		if value != nil {
+			values = append(values, value)
+		}
+	}
+	visit(err, nil)
+
+	return values
+}
@@ -0,0 +1,55 @@
+package errors
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_walk(t *testing.T) {
+	a, b, c, d := New("a"), New("b"), New("c"), New("d")
+	wrapped := Errorf("wrapped: %w", c)
+	joined := Join(b, wrapped)
+	err := Join(a, joined, d)
+
+	var visited []error
+	completed := walk(err, func(e error) bool {
+		visited = append(visited, e)
+		return true
+	})
+	if !completed {
+		t.Error("expected walk to complete")
+	}
+	expected := []error{err, a, joined, b, wrapped, c, d}
+	if !reflect.DeepEqual(visited, expected) {
+		t.Errorf("expected %v, got %v", expected, visited)
+	}
+
+	visited = nil
+	completed = walk(err, func(e error) bool {
+		visited = append(visited, e)
+		return e != b
+	})
+	if completed {
+		t.Error("expected walk to be stopped")
+	}
+	expected = []error{err, a, joined, b}
+	if !reflect.DeepEqual(visited, expected) {
+		t.Errorf("expected %v, got %v", expected, visited)
+	}
+}
+
+func Test_leafValues(t *testing.T) {
+	err := Join(
+		With(New("a"), NoOp, KV("key", "a")),
+		New("b"),
+		Errorf("%w", With(New("c"), NoOp, KV("key", "c"))),
+	)
+	if got := leafValues(err, "key"); !reflect.DeepEqual(got, []any{"a", "c"}) {
+		t.Errorf("expected [a c], got %v", got)
+	}
+
+	err = With(err, NoOp, KV("key", "top"))
+	if got := leafValues(err, "key"); !reflect.DeepEqual(got, []any{"top", "top", "top"}) {
+		t.Errorf("expected [top top top], got %v", got)
+	}
+}
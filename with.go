@@ -3,6 +3,7 @@ package errors
 import (
 	"reflect"
 	"runtime"
+	"slices"
 )
 
 var (
@@ -17,37 +18,79 @@ var (
 
 // With adds key-value pairs to an error, allowing for additional context.
 func With(err error, keyvalues ...KeyValuer) error {
+	return with(1, err, keyvalues...)
+}
+
+// with implements With. The argument skip is the number of stack frames to skip
+// when looking for the caller, with 1 identifying the caller of the function calling with.
+func with(skip int, err error, keyvalues ...KeyValuer) error {
 	if err == nil {
 		return nil
 	}
 
 	shouldAddAutomaticOp := AutomaticallyAddOp
+	hasStackTrace := false
 
+	keyvals := make([]KeyValuer, 0, len(keyvalues)+2)
 	for _, keyval := range keyvalues {
 		if !reflect.TypeOf(keyval.Key()).Comparable() {
 			panic(ErrKeyNotComparable)
 		}
-		if keyval.Key() == (opKey{}) {
+		switch keyval.Key() {
+		case opKey{}:
 			shouldAddAutomaticOp = false
-			if keyval.Value() == NoOp {
-				continue // NoOp means we don't want to add an Op
+			continue
+		case codeKey{}:
+			if code, ok := keyval.Value().(Code); ok {
+				checkRegisteredCode(code)
 			}
+		case stackTraceKey{}:
+			hasStackTrace = true
+		}
+		keyvals = append(keyvals, keyval)
+	}
+
+	if CaptureStackTrace && !hasStackTrace && Value(err, stackTraceKey{}) == nil {
+		keyvals = append(keyvals, captureStackTrace(skip+1))
+	}
+
+	// Ops are added last, so each Op wraps the values added with it. This is
+	// how Frames finds the values of each operation.
+	for _, keyval := range keyvalues {
+		if keyval.Key() == (opKey{}) && keyval.Value() != NoOp { // NoOp means we don't want to add an Op
+			keyvals = append(keyvals, keyval)
 		}
-		err = Error{err: err, keyval: keyval}
 	}
 
 	if shouldAddAutomaticOp {
-		return withAutomaticOp(err)
+		if op := automaticOp(skip + 1); ValueT[Op](err, opKey{}) != op {
+			keyvals = append(keyvals, op)
+		}
+	}
+
+	if len(keyvals) == 0 {
+		return err
 	}
 
-	return err
+	// The key-value pairs are stored from the most recent to the oldest.
+	slices.Reverse(keyvals)
+	return Error{err: err, keyvals: &keyvals}
 }
 
-func withAutomaticOp(err error) error {
-	pc, _, _, _ := runtime.Caller(2)
-	op := getCallerOp(pc, false)
-	if ValueT[Op](err, opKey{}) == op {
-		return err // Op already exists, no need to add it again
+// automaticOp returns the Op of the caller. The argument skip is the number of stack frames to skip,
+// with 0 identifying the caller of automaticOp.
+func automaticOp(skip int) Op {
+	// runtime.Callers is used instead of runtime.Caller because it doesn't resolve the
+	// function and location of the frame, which are cached by getCallerOp.
+	var pcs [1]uintptr
+	if runtime.Callers(skip+2, pcs[:]) == 0 {
+		return opUnknownFunction
+	}
+	// The pc is the return address, so it's decremented to point to the call instruction.
+	pc := pcs[0] - 1
+
+	if hasHelpers.Load() && isHelperPC(pc) {
+		return helperCallerOp(skip + 1)
 	}
-	return With(err, op)
+	return getCallerOp(pc, false)
 }
@@ -135,3 +135,92 @@ func Test_getCallerOp(t *testing.T) {
 		t.Errorf("Expected <unknown function>, got %s", result)
 	}
 }
+
+func BenchmarkWith(b *testing.B) {
+	rootErr := New("root error")
+
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = With(rootErr,
+			Op("op"),
+			Code("MY_CODE"),
+			SeverityInput,
+			KV("key1", "value1"),
+			KV("key2", "value2"),
+			KV("key3", "value3"),
+		)
+	}
+}
+
+func TestWithAddsSingleNode(t *testing.T) {
+	rootErr := New("root error")
+	err := With(rootErr, Op("op"), Code("MY_CODE"), SeverityInput, KV("key", "value"))
+
+	if unwrapped := Unwrap(err); unwrapped != rootErr {
+		t.Errorf("expected a single node wrapping the root error, got %#v", err)
+	}
+	// Comparing errors with the same dynamic type panics if the type is not comparable.
+	other := With(rootErr, Op("op"), Code("MY_CODE"), SeverityInput, KV("key", "value"))
+	if err == other {
+		t.Error("expected errors of different With calls to be different")
+	}
+	if got := Value(err, "key"); got != "value" {
+		t.Errorf("expected value %q, got %v", "value", got)
+	}
+
+	err = With(err, KV("key", "override"))
+	if got := Values(err, "key"); len(got) != 2 || got[0] != "override" || got[1] != "value" {
+		t.Errorf("expected values [override value], got %v", got)
+	}
+}
+
+func BenchmarkGetCallerOp(b *testing.B) {
+	pc, _, _, _ := runtime.Caller(0)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = getCallerOp(pc, false)
+	}
+}
+
+func BenchmarkWithAutomaticOp(b *testing.B) {
+	rootErr := New("root error")
+
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = With(rootErr, KV("key", "value"))
+	}
+}
+
+// withInlined is small enough to be inlined, so its frame only exists in the inlining tables.
+func withInlined(err error) error {
+	return With(err)
+}
+
+func TestWithAutomaticOpInlined(t *testing.T) {
+	err := withInlined(New("root error"))
+	if got := GetOpStack(err); got != "errors.withInlined" {
+		t.Errorf("expected op %q, got %q", "errors.withInlined", got)
+	}
+}
+
+func TestGetCallerOpCache(t *testing.T) {
+	pc, _, _, _ := runtime.Caller(0)
+
+	defer func(verbose bool) { VerboseOpOnAnonymousFunctions = verbose }(VerboseOpOnAnonymousFunctions)
+
+	VerboseOpOnAnonymousFunctions = true
+	verbose := getCallerOp(pc, false)
+	if cached := getCallerOp(pc, false); cached != verbose {
+		t.Errorf("expected cached op %q, got %q", verbose, cached)
+	}
+
+	if withLocation := getCallerOp(pc, true); withLocation == verbose {
+		t.Errorf("expected op with location, got %q", withLocation)
+	}
+
+	VerboseOpOnAnonymousFunctions = false
+	if got := getCallerOp(pc, false); got != verbose {
+		t.Errorf("expected op %q for a named function, got %q", verbose, got)
+	}
+}